@@ -0,0 +1,309 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy decides what AsyncHandler does with
+// incoming log when its queue is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits until there is a free space in the queue.
+	OverflowBlock = OverflowPolicy(iota)
+	// OverflowDropNewest drops incoming log.
+	OverflowDropNewest
+	// OverflowDropOldest drops the oldest log in the queue to make space for incoming log.
+	OverflowDropOldest
+)
+
+var (
+	ErrAsyncHandlerClosed   = errors.New("AsyncHandler - handler is closed")
+	ErrAsyncHandlerOverflow = errors.New("AsyncHandler - queue is full")
+)
+
+// AsyncHandler queues all logs and passes them in batches
+// to wrapped handler HandleBatch function. Batch is passed
+// when it reaches batch size or when flush interval passes.
+type AsyncHandler struct {
+	handler        Handler
+	queue          chan Log
+	batchSize      uint
+	interval       time.Duration
+	policy         OverflowPolicy
+	failureHandler FailureHandleFunc
+	dropped        uint64
+	closed         bool
+	closeLock      sync.RWMutex
+	pending        sync.WaitGroup
+	flushRequests  chan chan struct{}
+	closing        chan struct{}
+	stop           chan struct{}
+	done           chan struct{}
+}
+
+// NewAsyncHandler creates AsyncHandler with queue for "queueSize" logs
+// and starts background worker. Logs are passed to handler when
+// "batchSize" logs are collected or every "interval" (zero interval
+// disables periodic flushing).
+func NewAsyncHandler(handler Handler, queueSize uint, batchSize uint, interval time.Duration) *AsyncHandler {
+	if batchSize == 0 {
+		batchSize = 1
+	}
+
+	h := &AsyncHandler{
+		handler:       handler,
+		queue:         make(chan Log, queueSize),
+		batchSize:     batchSize,
+		interval:      interval,
+		flushRequests: make(chan chan struct{}),
+		closing:       make(chan struct{}),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+
+	go h.run()
+
+	return h
+}
+
+// WithOverflowPolicy changes behaviour for full queue (OverflowBlock by default).
+func (h *AsyncHandler) WithOverflowPolicy(policy OverflowPolicy) *AsyncHandler {
+	h.policy = policy
+
+	return h
+}
+
+// WithFailureHandler sets function called for every dropped log
+// and for every log in batch rejected by wrapped handler.
+func (h *AsyncHandler) WithFailureHandler(handleFunc FailureHandleFunc) *AsyncHandler {
+	h.failureHandler = handleFunc
+
+	return h
+}
+
+// Dropped returns number of logs dropped because of full queue.
+func (h *AsyncHandler) Dropped() uint64 {
+	return atomic.LoadUint64(&h.dropped)
+}
+
+func (h *AsyncHandler) Handle(log Log) error {
+	if !h.startEnqueue() {
+		return ErrAsyncHandlerClosed
+	}
+
+	defer h.pending.Done()
+
+	return h.enqueue(log)
+}
+
+func (h *AsyncHandler) HandleBatch(logs []Log) error {
+	if !h.startEnqueue() {
+		return ErrAsyncHandlerClosed
+	}
+
+	defer h.pending.Done()
+
+	for _, log := range logs {
+		err := h.enqueue(log)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// startEnqueue registers pending enqueue, so worker is not
+// stopped until it is finished. It returns false when handler is closed.
+func (h *AsyncHandler) startEnqueue() bool {
+	h.closeLock.RLock()
+	defer h.closeLock.RUnlock()
+
+	if h.closed {
+		return false
+	}
+
+	h.pending.Add(1)
+
+	return true
+}
+
+// Flush passes all queued logs to wrapped handler and waits
+// until it is done or context is cancelled.
+func (h *AsyncHandler) Flush(ctx context.Context) error {
+	h.closeLock.RLock()
+	closed := h.closed
+	h.closeLock.RUnlock()
+
+	if closed {
+		return ErrAsyncHandlerClosed
+	}
+
+	done := make(chan struct{})
+
+	select {
+	case h.flushRequests <- done:
+	case <-h.done:
+		return ErrAsyncHandlerClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting new logs, passes all queued logs to
+// wrapped handler and stops background worker. Close waits
+// until it is done or context is cancelled. When context is
+// cancelled, worker keeps draining the queue and next Close
+// calls can be used to wait for it again. Handle calls blocked
+// by full queue (OverflowBlock) return ErrAsyncHandlerClosed.
+func (h *AsyncHandler) Close(ctx context.Context) error {
+	h.closeLock.Lock()
+
+	if !h.closed {
+		h.closed = true
+		close(h.closing)
+
+		// worker is stopped after all pending enqueues are finished,
+		// so logs queued by them are not lost
+		go func() {
+			h.pending.Wait()
+			close(h.stop)
+		}()
+	}
+
+	h.closeLock.Unlock()
+
+	select {
+	case <-h.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (h *AsyncHandler) children() []Handler {
+	return []Handler{h.handler}
+}
+
+func (h *AsyncHandler) enqueue(log Log) error {
+	switch h.policy {
+	case OverflowDropNewest:
+		select {
+		case h.queue <- log:
+		default:
+			h.drop(log)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case h.queue <- log:
+				return nil
+			default:
+			}
+
+			select {
+			case old := <-h.queue:
+				h.drop(old)
+			default:
+			}
+		}
+	default:
+		select {
+		case h.queue <- log:
+		case <-h.closing:
+			return ErrAsyncHandlerClosed
+		}
+	}
+
+	return nil
+}
+
+func (h *AsyncHandler) drop(log Log) {
+	dropped := atomic.AddUint64(&h.dropped, 1)
+
+	if h.failureHandler != nil {
+		h.failureHandler(log, fmt.Errorf("%w (%d logs dropped in total)", ErrAsyncHandlerOverflow, dropped))
+	}
+}
+
+func (h *AsyncHandler) run() {
+	var tick <-chan time.Time
+	batch := make([]Log, 0, h.batchSize)
+
+	if h.interval > 0 {
+		ticker := time.NewTicker(h.interval)
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case log := <-h.queue:
+			batch = append(batch, log)
+
+			if uint(len(batch)) >= h.batchSize {
+				batch = h.flush(batch)
+			}
+		case <-tick:
+			batch = h.flush(batch)
+		case done := <-h.flushRequests:
+			batch = h.flush(h.drain(batch))
+			close(done)
+		case <-h.stop:
+			h.flush(h.drain(batch))
+			close(h.done)
+
+			return
+		}
+	}
+}
+
+func (h *AsyncHandler) drain(batch []Log) []Log {
+	for {
+		select {
+		case log := <-h.queue:
+			batch = append(batch, log)
+		default:
+			return batch
+		}
+	}
+}
+
+func (h *AsyncHandler) flush(batch []Log) []Log {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	err := h.handler.HandleBatch(batch)
+
+	if err != nil && h.failureHandler != nil {
+		failed := batch
+		rlErr := &RateLimitedError{}
+
+		// only dropped logs failed when batch was partially rate limited
+		if isIntentionalDrop(err) && errors.As(err, &rlErr) {
+			failed = rlErr.Dropped
+		}
+
+		for _, log := range failed {
+			h.failureHandler(log, fmt.Errorf("AsyncHandler - wrapped handler returned an error: %w", err))
+		}
+	}
+
+	return make([]Log, 0, h.batchSize)
+}
@@ -0,0 +1,298 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAsyncHandler_Handle(t *testing.T) {
+	t.Parallel()
+
+	logs := []Log{
+		{Level: LevelInfo, Message: "test 1", Data: make(Data), CreatedAt: time.Now()},
+		{Level: LevelError, Message: "test 2", Data: make(Data), CreatedAt: time.Now()},
+	}
+
+	mHandler := &mockHandler{}
+	mHandler.On("HandleBatch", logs).Return(nil).Once()
+
+	handler := NewAsyncHandler(mHandler, 10, 2, 0)
+
+	assert.NoError(t, handler.Handle(logs[0]))
+	assert.NoError(t, handler.Handle(logs[1]))
+	assert.NoError(t, handler.Close(context.Background()))
+
+	mHandler.AssertExpectations(t)
+	mHandler.AssertNumberOfCalls(t, "HandleBatch", 1)
+}
+
+func TestAsyncHandler_HandleBatch_Interval(t *testing.T) {
+	t.Parallel()
+
+	logs := []Log{
+		{Level: LevelInfo, Message: "test 1", Data: make(Data), CreatedAt: time.Now()},
+		{Level: LevelError, Message: "test 2", Data: make(Data), CreatedAt: time.Now()},
+	}
+
+	memory := NewInMemoryHandler(0)
+	handler := NewAsyncHandler(memory, 10, 100, time.Millisecond)
+
+	assert.NoError(t, handler.HandleBatch(logs))
+	assert.Eventually(t, func() bool {
+		return !memory.IsEmpty()
+	}, time.Second, time.Millisecond)
+
+	assert.Equal(t, logs[1], memory.Pop())
+	assert.Equal(t, logs[0], memory.Pop())
+	assert.NoError(t, handler.Close(context.Background()))
+}
+
+func TestAsyncHandler_Flush(t *testing.T) {
+	t.Parallel()
+
+	memory := NewInMemoryHandler(0)
+	handler := NewAsyncHandler(memory, 100, 100, 0)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(50)
+
+	for i := 1; i <= 50; i++ {
+		go func() {
+			assert.NoError(t, handler.Handle(Log{Level: LevelInfo, Message: "test", Data: make(Data), CreatedAt: time.Now()}))
+			wg.Done()
+		}()
+	}
+
+	wg.Wait()
+
+	assert.True(t, memory.IsEmpty())
+	assert.NoError(t, handler.Flush(context.Background()))
+
+	for i := 1; i <= 50; i++ {
+		memory.Pop()
+	}
+
+	assert.True(t, memory.IsEmpty())
+	assert.NoError(t, handler.Close(context.Background()))
+}
+
+func TestAsyncHandler_Close(t *testing.T) {
+	t.Parallel()
+
+	log := Log{Level: LevelInfo, Message: "test", Data: make(Data), CreatedAt: time.Now()}
+
+	memory := NewInMemoryHandler(0)
+	handler := NewAsyncHandler(memory, 10, 10, 0)
+
+	assert.NoError(t, handler.Handle(log))
+	assert.NoError(t, handler.Close(context.Background()))
+	assert.Equal(t, log, memory.Pop())
+
+	assert.Equal(t, ErrAsyncHandlerClosed, handler.Handle(log))
+	assert.Equal(t, ErrAsyncHandlerClosed, handler.HandleBatch([]Log{log}))
+	assert.Equal(t, ErrAsyncHandlerClosed, handler.Flush(context.Background()))
+	assert.NoError(t, handler.Close(context.Background()))
+}
+
+func TestAsyncHandler_Close_ContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	log := Log{Level: LevelInfo, Message: "test", Data: make(Data), CreatedAt: time.Now()}
+
+	started := make(chan struct{})
+	blocking := make(chan struct{})
+	mHandler := &mockHandler{}
+	mHandler.On("HandleBatch", []Log{log}).Run(func(_ mock.Arguments) {
+		close(started)
+		<-blocking
+	}).Return(nil).Once()
+
+	handler := NewAsyncHandler(mHandler, 10, 1, 0)
+
+	assert.NoError(t, handler.Handle(log))
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Equal(t, context.Canceled, handler.Close(ctx))
+	assert.Equal(t, ErrAsyncHandlerClosed, handler.Handle(log))
+
+	flushed := make(chan error)
+
+	go func() {
+		flushed <- handler.Flush(context.Background())
+	}()
+
+	close(blocking)
+
+	assert.Equal(t, ErrAsyncHandlerClosed, <-flushed)
+	assert.NoError(t, handler.Close(context.Background()))
+	mHandler.AssertExpectations(t)
+}
+
+func TestAsyncHandler_Close_BlockedHandle(t *testing.T) {
+	t.Parallel()
+
+	logs := []Log{
+		{Level: LevelInfo, Message: "test 1", Data: make(Data), CreatedAt: time.Now()},
+		{Level: LevelInfo, Message: "test 2", Data: make(Data), CreatedAt: time.Now()},
+		{Level: LevelInfo, Message: "test 3", Data: make(Data), CreatedAt: time.Now()},
+	}
+
+	started := make(chan struct{})
+	blocking := make(chan struct{})
+	mHandler := &mockHandler{}
+	mHandler.On("HandleBatch", []Log{logs[0]}).Run(func(_ mock.Arguments) {
+		close(started)
+		<-blocking
+	}).Return(nil).Once()
+	mHandler.On("HandleBatch", []Log{logs[1]}).Return(nil).Once()
+
+	handler := NewAsyncHandler(mHandler, 1, 1, 0)
+
+	assert.NoError(t, handler.Handle(logs[0]))
+	<-started
+	assert.NoError(t, handler.Handle(logs[1]))
+
+	blocked := make(chan error)
+
+	go func() {
+		blocked <- handler.Handle(logs[2])
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.Equal(t, context.DeadlineExceeded, handler.Close(ctx))
+	assert.Equal(t, ErrAsyncHandlerClosed, <-blocked)
+
+	close(blocking)
+
+	assert.NoError(t, handler.Close(context.Background()))
+	mHandler.AssertExpectations(t)
+}
+
+func TestAsyncHandler_HandlerFailure_RateLimited(t *testing.T) {
+	t.Parallel()
+
+	var failed []Log
+
+	logs := []Log{
+		{Level: LevelInfo, Message: "test 1"},
+		{Level: LevelInfo, Message: "test 2"},
+	}
+
+	memory := NewInMemoryHandler(0)
+	limited := NewRateLimitedHandler(memory, map[Level]RateLimit{LevelInfo: {Rate: 1}}).
+		WithClock(newManualClock(time.Now()))
+	handler := NewAsyncHandler(limited, 10, 2, 0).WithFailureHandler(func(log Log, err error) {
+		assert.True(t, errors.Is(err, ErrRateLimited))
+		failed = append(failed, log)
+	})
+
+	assert.NoError(t, handler.HandleBatch(logs))
+	assert.NoError(t, handler.Close(context.Background()))
+
+	assert.Equal(t, []Log{logs[1]}, failed)
+	assert.Equal(t, "test 1", memory.Pop().Message)
+}
+
+func TestAsyncHandler_OverflowPolicy(t *testing.T) {
+	t.Parallel()
+
+	logs := []Log{
+		{Level: LevelInfo, Message: "test 1", Data: make(Data), CreatedAt: time.Now()},
+		{Level: LevelInfo, Message: "test 2", Data: make(Data), CreatedAt: time.Now()},
+		{Level: LevelInfo, Message: "test 3", Data: make(Data), CreatedAt: time.Now()},
+	}
+
+	t.Run("drop newest", func(t *testing.T) {
+		var droppedLogs []Log
+
+		started := make(chan struct{})
+		blocking := make(chan struct{})
+		mHandler := &mockHandler{}
+		mHandler.On("HandleBatch", []Log{logs[0]}).Run(func(_ mock.Arguments) {
+			close(started)
+			<-blocking
+		}).Return(nil).Once()
+		mHandler.On("HandleBatch", []Log{logs[1]}).Return(nil).Once()
+
+		handler := NewAsyncHandler(mHandler, 1, 1, 0).
+			WithOverflowPolicy(OverflowDropNewest).
+			WithFailureHandler(func(log Log, err error) {
+				assert.True(t, errors.Is(err, ErrAsyncHandlerOverflow))
+				droppedLogs = append(droppedLogs, log)
+			})
+
+		assert.NoError(t, handler.Handle(logs[0]))
+		<-started
+
+		assert.NoError(t, handler.Handle(logs[1]))
+		assert.NoError(t, handler.Handle(logs[2]))
+		close(blocking)
+
+		assert.NoError(t, handler.Close(context.Background()))
+		assert.Equal(t, []Log{logs[2]}, droppedLogs)
+		assert.Equal(t, uint64(1), handler.Dropped())
+		mHandler.AssertExpectations(t)
+	})
+
+	t.Run("drop oldest", func(t *testing.T) {
+		var droppedLogs []Log
+
+		started := make(chan struct{})
+		blocking := make(chan struct{})
+		mHandler := &mockHandler{}
+		mHandler.On("HandleBatch", []Log{logs[0]}).Run(func(_ mock.Arguments) {
+			close(started)
+			<-blocking
+		}).Return(nil).Once()
+		mHandler.On("HandleBatch", []Log{logs[2]}).Return(nil).Once()
+
+		handler := NewAsyncHandler(mHandler, 1, 1, 0).
+			WithOverflowPolicy(OverflowDropOldest).
+			WithFailureHandler(func(log Log, err error) {
+				assert.True(t, errors.Is(err, ErrAsyncHandlerOverflow))
+				droppedLogs = append(droppedLogs, log)
+			})
+
+		assert.NoError(t, handler.Handle(logs[0]))
+		<-started
+
+		assert.NoError(t, handler.Handle(logs[1]))
+		assert.NoError(t, handler.Handle(logs[2]))
+		close(blocking)
+
+		assert.NoError(t, handler.Close(context.Background()))
+		assert.Equal(t, []Log{logs[1]}, droppedLogs)
+		assert.Equal(t, uint64(1), handler.Dropped())
+		mHandler.AssertExpectations(t)
+	})
+}
+
+func TestAsyncHandler_HandlerFailure(t *testing.T) {
+	t.Parallel()
+
+	var failedLogs []Log
+	log := Log{Level: LevelInfo, Message: "test", Data: make(Data), CreatedAt: time.Now()}
+
+	mHandler := &mockHandler{}
+	mHandler.On("HandleBatch", mock.Anything).Return(errors.New("test"))
+
+	handler := NewAsyncHandler(mHandler, 10, 10, 0).WithFailureHandler(func(log Log, err error) {
+		assert.EqualError(t, err, "AsyncHandler - wrapped handler returned an error: test")
+		failedLogs = append(failedLogs, log)
+	})
+
+	assert.NoError(t, handler.Handle(log))
+	assert.NoError(t, handler.Flush(context.Background()))
+	assert.Equal(t, []Log{log}, failedLogs)
+	assert.NoError(t, handler.Close(context.Background()))
+}
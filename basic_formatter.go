@@ -2,8 +2,6 @@ package logger
 
 import (
 	"errors"
-	"fmt"
-	"sort"
 	"strings"
 )
 
@@ -37,39 +35,39 @@ func (f *BasicFormatter) createFormattedMessage(log Log) string {
 	res.WriteString(" | ")
 	res.WriteString(strings.ToUpper(ln.String()))
 	res.WriteString(" | ")
-	res.WriteString(f.applyDataOnMessage(log.Message, log.Data))
 
-	if log.Data.Len() > 0 {
+	if log.Name != "" {
+		res.WriteString(log.Name)
 		res.WriteString(" | ")
-		res.WriteString(f.createDataSection(log.Data))
 	}
 
-	return res.String()
-}
+	data := log.Data.withFields(log.Fields)
+	res.WriteString(applyDataOnMessage(log.Message, data))
+
+	if log.Caller != nil {
+		res.WriteString(" | ")
+		res.WriteString(log.Caller.String())
+	}
 
-func (f *BasicFormatter) applyDataOnMessage(message string, data Data) string {
-	if data.Len() < 1 {
-		return message
+	if data.Len() > 0 {
+		res.WriteString(" | ")
+		res.WriteString(f.createDataSection(data))
 	}
 
-	for key, val := range data {
-		message = strings.ReplaceAll(message, fmt.Sprintf("{%s}", key), val)
+	for _, frame := range log.Stack {
+		res.WriteString("\n\t")
+		res.WriteString(frame.Function)
+		res.WriteString("\n\t\t")
+		res.WriteString(frame.String())
 	}
 
-	return message
+	return res.String()
 }
 
 func (f *BasicFormatter) createDataSection(data Data) string {
-	var keys []string
 	res := &strings.Builder{}
 
-	for key := range data {
-		keys = append(keys, key)
-	}
-
-	sort.Strings(keys)
-
-	for _, key := range keys {
+	for _, key := range sortedDataKeys(data) {
 		res.WriteString(key)
 		res.WriteString(":")
 		res.WriteString(data[key])
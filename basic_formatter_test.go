@@ -44,4 +44,82 @@ func TestBasicFormatter_Format(t *testing.T) {
 			formatted.FormattedMessage,
 		)
 	})
+
+	t.Run("with logger name", func(t *testing.T) {
+		tm := time.Now()
+
+		formatter := NewBasicFormatter("testing", time.RFC3339)
+		formatted := formatter.Format(Log{
+			Level:     LevelInfo,
+			Name:      "db.pool",
+			Message:   "test message",
+			Data:      Data{},
+			CreatedAt: tm,
+		})
+
+		assert.Equal(t, fmt.Sprintf("testing | %s | INFO | db.pool | test message", tm.Format(time.RFC3339)), formatted.FormattedMessage)
+	})
+
+	t.Run("with caller", func(t *testing.T) {
+		tm := time.Now()
+
+		formatter := NewBasicFormatter("testing", time.RFC3339)
+		formatted := formatter.Format(Log{
+			Level:     LevelDebug,
+			Message:   "test message",
+			Data:      Data{"key": "val"},
+			CreatedAt: tm,
+			Caller:    &Caller{File: "/app/main.go", Line: 42, Function: "main.main"},
+		})
+
+		assert.Equal(
+			t,
+			fmt.Sprintf("testing | %s | DEBUG | test message | /app/main.go:42 | key:val", tm.Format(time.RFC3339)),
+			formatted.FormattedMessage,
+		)
+	})
+
+	t.Run("with stack trace", func(t *testing.T) {
+		tm := time.Now()
+
+		formatter := NewBasicFormatter("testing", time.RFC3339)
+		formatted := formatter.Format(Log{
+			Level:     LevelError,
+			Message:   "test message",
+			Data:      Data{},
+			CreatedAt: tm,
+			Stack: StackTrace{
+				{File: "/app/service.go", Line: 12, Function: "app.run"},
+				{File: "/app/main.go", Line: 42, Function: "main.main"},
+			},
+		})
+
+		assert.Equal(
+			t,
+			fmt.Sprintf(
+				"testing | %s | ERROR | test message\n\tapp.run\n\t\t/app/service.go:12\n\tmain.main\n\t\t/app/main.go:42",
+				tm.Format(time.RFC3339),
+			),
+			formatted.FormattedMessage,
+		)
+	})
+
+	t.Run("with fields", func(t *testing.T) {
+		tm := time.Now()
+
+		formatter := NewBasicFormatter("testing", time.RFC3339)
+		formatted := formatter.Format(Log{
+			Level:     LevelInfo,
+			Message:   "took {took}",
+			Data:      Data{"key": "val", "answer": "41"},
+			Fields:    Fields{Int("answer", 42), Duration("took", time.Second)},
+			CreatedAt: tm,
+		})
+
+		assert.Equal(
+			t,
+			fmt.Sprintf("testing | %s | INFO | took 1s | answer:42 key:val took:1s", tm.Format(time.RFC3339)),
+			formatted.FormattedMessage,
+		)
+	})
 }
@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Caller contains information about place in code
+// where log was created.
+type Caller struct {
+	File     string
+	Line     int
+	Function string
+}
+
+var (
+	packagePath = reflect.TypeOf(MainLogger{}).PkgPath()
+	// internalCallers contains prefixes of functions which
+	// are skipped while looking for caller of logging function.
+	internalCallers = []string{
+		packagePath + ".(*MainLogger)",
+		packagePath + ".(*ErrorWrappedLogger)",
+		packagePath + ".(*StdWriter)",
+		"log.",
+	}
+)
+
+// String returns caller in "file:line" format.
+func (c *Caller) String() string {
+	return fmt.Sprintf("%s:%d", c.File, c.Line)
+}
+
+// captureCaller returns first caller outside of logger
+// functions. Additional "skip" frames are skipped after that
+// so wrappers created outside of package can be omitted.
+func captureCaller(skip int) *Caller {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+
+		if !isInternalCaller(frame.Function) {
+			if skip <= 0 {
+				return &Caller{File: frame.File, Line: frame.Line, Function: frame.Function}
+			}
+
+			skip--
+		}
+
+		if !more {
+			return nil
+		}
+	}
+}
+
+func isInternalCaller(function string) bool {
+	for _, prefix := range internalCallers {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
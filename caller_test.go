@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestMainLogger_WithCaller(t *testing.T) {
+	t.Parallel()
+
+	handler := NewInMemoryHandler(0)
+	logger := New(handler).WithCaller(0)
+
+	_, _, line, _ := runtime.Caller(0)
+	logger.Info("test")
+	logger.With("key", "val").Warningf("test %s", "formatted")
+	NewErrorWrappedLogger(logger).OnError(errors.New("test"))
+
+	for i := 3; i > 0; i-- {
+		log := handler.Pop()
+
+		if assert.NotNil(t, log.Caller) {
+			assert.Equal(t, "caller_test.go", filepath.Base(log.Caller.File))
+			assert.Equal(t, line+i, log.Caller.Line)
+			assert.Equal(t, packagePath+".TestMainLogger_WithCaller", log.Caller.Function)
+		}
+	}
+}
+
+func TestMainLogger_WithCaller_ExtraSkip(t *testing.T) {
+	t.Parallel()
+
+	handler := NewInMemoryHandler(0)
+	logger := New(handler).WithCaller(1)
+
+	wrapper := func(message string) {
+		logger.Error(message)
+	}
+
+	_, _, line, _ := runtime.Caller(0)
+	wrapper("test")
+
+	log := handler.Pop()
+
+	if assert.NotNil(t, log.Caller) {
+		assert.Equal(t, line+1, log.Caller.Line)
+		assert.Equal(t, packagePath+".TestMainLogger_WithCaller_ExtraSkip", log.Caller.Function)
+	}
+}
+
+func TestMainLogger_WithoutCaller(t *testing.T) {
+	t.Parallel()
+
+	handler := NewInMemoryHandler(0)
+	logger := New(handler)
+
+	logger.Info("test")
+
+	assert.Nil(t, handler.Pop().Caller)
+}
+
+func TestCaller_String(t *testing.T) {
+	t.Parallel()
+
+	caller := &Caller{File: "/app/main.go", Line: 42, Function: "main.main"}
+
+	assert.Equal(t, "/app/main.go:42", caller.String())
+}
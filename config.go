@@ -1,14 +1,113 @@
 package logger
 
-import "sync"
+import (
+	"strings"
+	"sync"
+)
 
 type config struct {
-	level Level
-	lock  sync.RWMutex
+	level      Level
+	namedLevel map[string]Level
+	lock       sync.RWMutex
+}
+
+// settings are options of MainLogger changed with its With* functions.
+type settings struct {
+	clock           Clock
+	criticalHandler CriticalHandleFunc
+	failureHandler  FailureHandleFunc
+	fallbackHandler Handler
+	withCaller      bool
+	callerSkip      int
+	withStack       bool
+	stackLevel      Level
+	extractors      []ContextExtractFunc
+}
+
+// settingField marks settings changed in settingsLayer.
+type settingField int
+
+const (
+	settingClock = settingField(1 << iota)
+	settingCriticalHandler
+	settingFailureHandler
+	settingFallbackHandler
+	settingCaller
+	settingStackTrace
+)
+
+// settingsLayer keeps settings changed on single logger. Loggers created with
+// With and Named get their own layer on top of parent layer, so settings changed
+// on parent (also after child was created) apply to child unless child changed
+// them itself, while settings changed on child do not affect parent and siblings.
+type settingsLayer struct {
+	parent *settingsLayer
+	values settings
+	set    settingField
+	lock   sync.RWMutex
 }
 
 func newConfig(level Level) *config {
-	return &config{level: level}
+	return &config{level: level, namedLevel: make(map[string]Level)}
+}
+
+func newSettingsLayer(parent *settingsLayer) *settingsLayer {
+	if parent == nil {
+		return &settingsLayer{values: settings{clock: NewDefaultClock()}, set: settingClock}
+	}
+
+	return &settingsLayer{parent: parent}
+}
+
+// get returns settings of parent layers overridden by settings changed in this layer.
+// Context extractors of this layer are executed after extractors of parent layers.
+func (sl *settingsLayer) get() settings {
+	var s settings
+
+	if sl.parent != nil {
+		s = sl.parent.get()
+	}
+
+	sl.lock.RLock()
+	defer sl.lock.RUnlock()
+
+	if sl.set&settingClock != 0 {
+		s.clock = sl.values.clock
+	}
+
+	if sl.set&settingCriticalHandler != 0 {
+		s.criticalHandler = sl.values.criticalHandler
+	}
+
+	if sl.set&settingFailureHandler != 0 {
+		s.failureHandler = sl.values.failureHandler
+	}
+
+	if sl.set&settingFallbackHandler != 0 {
+		s.fallbackHandler = sl.values.fallbackHandler
+	}
+
+	if sl.set&settingCaller != 0 {
+		s.withCaller, s.callerSkip = sl.values.withCaller, sl.values.callerSkip
+	}
+
+	if sl.set&settingStackTrace != 0 {
+		s.withStack, s.stackLevel = sl.values.withStack, sl.values.stackLevel
+	}
+
+	if len(sl.values.extractors) > 0 {
+		s.extractors = append(s.extractors[:len(s.extractors):len(s.extractors)], sl.values.extractors...)
+	}
+
+	return s
+}
+
+func (sl *settingsLayer) update(field settingField, update func(s *settings)) {
+	sl.lock.Lock()
+	defer sl.lock.Unlock()
+
+	update(&sl.values)
+	sl.set |= field
 }
 
 func (c *config) getLevel() Level {
@@ -24,3 +123,55 @@ func (c *config) setLevel(level Level) {
 
 	c.level = level
 }
+
+// getNamedLevel returns level set for logger name or for the closest
+// dot-separated parent name ("db" for "db.pool"). Default level
+// is returned when none of them has its own level.
+func (c *config) getNamedLevel(name string) Level {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	for name != "" {
+		if level, ok := c.namedLevel[name]; ok {
+			return level
+		}
+
+		idx := strings.LastIndexByte(name, '.')
+
+		if idx < 0 {
+			break
+		}
+
+		name = name[:idx]
+	}
+
+	return c.level
+}
+
+func (c *config) setNamedLevel(name string, level Level) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.namedLevel[name] = level
+}
+
+func (c *config) resetNamedLevel(name string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.namedLevel, name)
+}
+
+// getNamedLevels returns copy of all levels set for logger names.
+func (c *config) getNamedLevels() map[string]Level {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	res := make(map[string]Level, len(c.namedLevel))
+
+	for name, level := range c.namedLevel {
+		res[name] = level
+	}
+
+	return res
+}
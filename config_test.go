@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"github.com/stretchr/testify/assert"
 	"sync"
 	"testing"
 )
@@ -38,3 +39,23 @@ func TestConfig(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestConfig_NamedLevel(t *testing.T) {
+	t.Parallel()
+
+	c := newConfig(LevelInfo)
+	c.setNamedLevel("db", LevelDebug)
+	c.setNamedLevel("db.pool.conn", LevelError)
+
+	assert.Equal(t, LevelInfo, c.getNamedLevel(""))
+	assert.Equal(t, LevelInfo, c.getNamedLevel("http"))
+	assert.Equal(t, LevelInfo, c.getNamedLevel("dbx"))
+	assert.Equal(t, LevelDebug, c.getNamedLevel("db"))
+	assert.Equal(t, LevelDebug, c.getNamedLevel("db.pool"))
+	assert.Equal(t, LevelError, c.getNamedLevel("db.pool.conn"))
+	assert.Equal(t, LevelError, c.getNamedLevel("db.pool.conn.tcp"))
+	assert.Equal(t, map[string]Level{"db": LevelDebug, "db.pool.conn": LevelError}, c.getNamedLevels())
+
+	c.resetNamedLevel("db.pool.conn")
+	assert.Equal(t, LevelDebug, c.getNamedLevel("db.pool.conn"))
+}
@@ -0,0 +1,326 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+// ConfigWatcher creates MainLogger from LoggerConfig file and reloads it when
+// file content changes. On reload, new handler tree and levels replace the old
+// ones atomically: logs being handled by old handlers are finished first, then
+// old handlers are closed (e.g. FileHandler.Close). When new config is invalid,
+// the old one is kept and error is passed to FailureHandleFunc.
+type ConfigWatcher struct {
+	path           string
+	unmarshal      UnmarshalFunc
+	filesystem     Filesystem
+	clock          Clock
+	lookup         func(key string) (string, bool)
+	newTicker      TickerFunc
+	failureHandler FailureHandleFunc
+	lock           sync.Mutex
+	logger         *MainLogger
+	handler        *reloadableHandler
+	content        []byte
+	done           chan struct{}
+	wg             sync.WaitGroup
+}
+
+// TickerFunc creates source of ticks sent every "interval"
+// and function stopping it (e.g. wrapping time.NewTicker).
+type TickerFunc func(interval time.Duration) (ticks <-chan time.Time, stop func())
+
+// reloadableHandler passes logs to current handler,
+// which can be swapped without losing in-flight logs.
+type reloadableHandler struct {
+	lock    sync.RWMutex
+	handler Handler
+}
+
+// NewConfigWatcher creates ConfigWatcher for config file at "path"
+// decoded with "unmarshal" function (JSON when nil).
+func NewConfigWatcher(path string, unmarshal UnmarshalFunc) *ConfigWatcher {
+	return &ConfigWatcher{
+		path:       path,
+		unmarshal:  unmarshal,
+		filesystem: NewDefaultFilesystem(),
+		clock:      NewDefaultClock(),
+		lookup:     os.LookupEnv,
+		newTicker:  newTimeTicker,
+	}
+}
+
+func newTimeTicker(interval time.Duration) (<-chan time.Time, func()) {
+	ticker := time.NewTicker(interval)
+
+	return ticker.C, ticker.Stop
+}
+
+// WithFilesystem allows to replace default Filesystem
+// implementation used to read config file.
+func (w *ConfigWatcher) WithFilesystem(filesystem Filesystem) *ConfigWatcher {
+	w.filesystem = filesystem
+
+	return w
+}
+
+// WithClock allows to set custom implementation for Clock
+// interface used for created logger and reload failure logs.
+func (w *ConfigWatcher) WithClock(clock Clock) *ConfigWatcher {
+	w.clock = clock
+
+	return w
+}
+
+// WithTicker allows to replace time.Ticker used by Start
+// to decide when config file is checked for changes.
+func (w *ConfigWatcher) WithTicker(newTicker TickerFunc) *ConfigWatcher {
+	w.newTicker = newTicker
+
+	return w
+}
+
+// WithFailureHandler sets function called when config cannot be reloaded.
+func (w *ConfigWatcher) WithFailureHandler(handleFunc FailureHandleFunc) *ConfigWatcher {
+	w.failureHandler = handleFunc
+
+	return w
+}
+
+// Load reads config file and creates MainLogger described by it.
+// Subsequent calls return the same logger.
+func (w *ConfigWatcher) Load() (*MainLogger, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.logger != nil {
+		return w.logger, nil
+	}
+
+	c, content, lErr := w.readConfig()
+
+	if lErr != nil {
+		return nil, lErr
+	}
+
+	handler, bErr := BuildHandler(c.Handler)
+
+	if bErr != nil {
+		return nil, bErr
+	}
+
+	w.handler = &reloadableHandler{handler: handler}
+	l := New(w.handler).WithClock(w.clock)
+	aErr := c.applyLevels(l)
+
+	if aErr != nil {
+		return nil, aErr
+	}
+
+	w.logger = l
+	w.content = content
+
+	return l, nil
+}
+
+// Reload reads config file and, if its content changed since last
+// load, replaces handler tree and levels of the logger.
+func (w *ConfigWatcher) Reload() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.logger == nil {
+		return fmt.Errorf("ConfigWatcher - config must be loaded before reload")
+	}
+
+	c, content, lErr := w.readConfig()
+
+	if lErr != nil {
+		return lErr
+	}
+
+	if bytes.Equal(content, w.content) {
+		return nil
+	}
+
+	level, named, pErr := c.parseLevels()
+
+	if pErr != nil {
+		return pErr
+	}
+
+	handler, bErr := BuildHandler(c.Handler)
+
+	if bErr != nil {
+		return bErr
+	}
+
+	old := w.handler.swap(handler, func() {
+		setLevels(w.logger, level, named)
+	})
+	w.content = content
+
+	cErr := closeHandler(old)
+
+	if cErr != nil {
+		return fmt.Errorf("ConfigWatcher - unable to close replaced handler: %w", cErr)
+	}
+
+	return nil
+}
+
+// Start checks config file for changes every "interval" (or on every
+// tick of ticker set with WithTicker) in background.
+func (w *ConfigWatcher) Start(interval time.Duration) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.done != nil {
+		return
+	}
+
+	done := make(chan struct{})
+	ticks, stop := w.newTicker(interval)
+	w.done = done
+	w.wg.Add(1)
+
+	go func() {
+		defer w.wg.Done()
+		defer stop()
+
+		for {
+			select {
+			case <-ticks:
+				w.reloadWithFailureHandler()
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops checking config file for changes.
+func (w *ConfigWatcher) Stop() {
+	w.lock.Lock()
+	done := w.done
+	w.done = nil
+	w.lock.Unlock()
+
+	if done == nil {
+		return
+	}
+
+	close(done)
+	w.wg.Wait()
+}
+
+func (w *ConfigWatcher) reloadWithFailureHandler() {
+	err := w.Reload()
+
+	if err != nil && w.failureHandler != nil {
+		w.failureHandler(Log{
+			Level:     LevelError,
+			Message:   "ConfigWatcher - unable to reload logger config",
+			Data:      Data{"path": w.path},
+			CreatedAt: w.clock.Now(),
+		}, err)
+	}
+}
+
+func (w *ConfigWatcher) readConfig() (*LoggerConfig, []byte, error) {
+	unmarshal := w.unmarshal
+
+	if unmarshal == nil {
+		unmarshal = json.Unmarshal
+	}
+
+	file, oErr := w.filesystem.Open(w.path)
+
+	if oErr != nil {
+		return nil, nil, fmt.Errorf("ConfigWatcher - unable to open config file: %w", oErr)
+	}
+
+	defer file.Close()
+
+	content, rErr := ioutil.ReadAll(file)
+
+	if rErr != nil {
+		return nil, nil, fmt.Errorf("ConfigWatcher - unable to read config file: %w", rErr)
+	}
+
+	c, pErr := ParseConfigWith(content, unmarshal)
+
+	if pErr != nil {
+		return nil, nil, pErr
+	}
+
+	c.ApplyEnv(w.lookup)
+
+	return c, content, nil
+}
+
+func (h *reloadableHandler) Handle(log Log) error {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.handler.Handle(log)
+}
+
+func (h *reloadableHandler) HandleBatch(logs []Log) error {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.handler.HandleBatch(logs)
+}
+
+// current returns handler which receives logs.
+func (h *reloadableHandler) current() Handler {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.handler
+}
+
+func (h *reloadableHandler) children() []Handler {
+	return []Handler{h.current()}
+}
+
+// swap waits for logs being handled, replaces handler and executes
+// "apply" function before any log is passed to the new handler.
+func (h *reloadableHandler) swap(handler Handler, apply func()) Handler {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	old := h.handler
+	h.handler = handler
+	apply()
+
+	return old
+}
+
+// closeHandler closes handler and all handlers grouped
+// or wrapped by it (e.g. FileHandler wrapped by AsyncHandler).
+// Wrapping handlers are closed first, so they can flush their logs.
+func closeHandler(handler Handler) error {
+	var errs []error
+
+	for _, h := range handlerTree(handler) {
+		switch c := h.(type) {
+		case interface{ Close() error }:
+			errs = append(errs, c.Close())
+		case interface {
+			Close(ctx context.Context) error
+		}:
+			errs = append(errs, c.Close(context.Background()))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,224 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func init() {
+	_ = RegisterHandlerType("config_watcher_test", func(config HandlerConfig) (Handler, error) {
+		id, _ := config.Options["id"].(string)
+
+		return &closableTestHandler{InMemoryHandler: configTestHandler(id), id: id}, nil
+	})
+}
+
+var closedTestHandlers sync.Map
+
+type closableTestHandler struct {
+	*InMemoryHandler
+	id string
+}
+
+func (h *closableTestHandler) Close() error {
+	closedTestHandlers.Store(h.id, true)
+
+	return nil
+}
+
+func writeTestConfig(fs *memoryFilesystem, path string, content string) {
+	f, _ := fs.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0)
+	_, _ = f.WriteString(content)
+}
+
+func TestConfigWatcher_Reload(t *testing.T) {
+	t.Parallel()
+	resetConfigTestHandlers("reload-1", "reload-2")
+
+	fs := newMemoryFilesystem(NewDefaultClock())
+	writeTestConfig(fs, "/etc/logger.json", `{"level": "info", "named_levels": {"db": "debug"},
+		"handler": {"type": "config_watcher_test", "options": {"id": "reload-1"}}}`)
+
+	watcher := NewConfigWatcher("/etc/logger.json", nil).WithFilesystem(fs)
+	watcher.lookup = func(string) (string, bool) {
+		return "", false
+	}
+
+	assert.EqualError(t, watcher.Reload(), "ConfigWatcher - config must be loaded before reload")
+
+	l, lErr := watcher.Load()
+
+	if !assert.NoError(t, lErr) {
+		return
+	}
+
+	l.Debug("skipped")
+	l.Info("first")
+	assert.Equal(t, LevelDebug, l.Named("db").Level())
+
+	assert.NoError(t, watcher.Reload())
+	_, closed := closedTestHandlers.Load("reload-1")
+	assert.False(t, closed)
+
+	writeTestConfig(fs, "/etc/logger.json", `{"level": "debug",
+		"handler": {"type": "multi", "handlers": [{"type": "config_watcher_test", "options": {"id": "reload-2"}}]}}`)
+	assert.NoError(t, watcher.Reload())
+
+	l.Debug("second")
+
+	_, closed = closedTestHandlers.Load("reload-1")
+	assert.True(t, closed)
+	assert.Empty(t, l.NamedLevels())
+	assert.Equal(t, "first", configTestHandler("reload-1").Pop().Message)
+	assert.True(t, configTestHandler("reload-1").IsEmpty())
+	assert.Equal(t, "second", configTestHandler("reload-2").Pop().Message)
+
+	writeTestConfig(fs, "/etc/logger.json", `{"level": "x", "handler": {"type": "memory"}}`)
+	assert.EqualError(t, watcher.Reload(), "LoggerConfig - invalid level: level cannot be mapped to level name: \"x\"")
+
+	l.Debug("third")
+	assert.Equal(t, "third", configTestHandler("reload-2").Pop().Message)
+}
+
+func TestConfigWatcher_Start(t *testing.T) {
+	t.Parallel()
+	resetConfigTestHandlers("start-1", "start-2")
+
+	fs := newMemoryFilesystem(NewDefaultClock())
+	writeTestConfig(fs, "/etc/logger.json", `{"handler": {"type": "config_watcher_test", "options": {"id": "start-1"}}}`)
+
+	var failures []error
+	var interval time.Duration
+
+	ticks := make(chan time.Time)
+	stopped := false
+	watcher := NewConfigWatcher("/etc/logger.json", nil).
+		WithFilesystem(fs).
+		WithTicker(func(d time.Duration) (<-chan time.Time, func()) {
+			interval = d
+
+			return ticks, func() {
+				stopped = true
+			}
+		}).
+		WithFailureHandler(func(log Log, err error) {
+			failures = append(failures, err)
+		})
+
+	l, lErr := watcher.Load()
+
+	if !assert.NoError(t, lErr) {
+		return
+	}
+
+	watcher.Start(time.Minute)
+	assert.Equal(t, time.Minute, interval)
+
+	writeTestConfig(fs, "/etc/logger.json", `{"handler": {"type": "unknown"}}`)
+	ticks <- time.Now()
+
+	writeTestConfig(fs, "/etc/logger.json", `{"handler": {"type": "config_watcher_test", "options": {"id": "start-2"}}}`)
+	ticks <- time.Now()
+	watcher.Stop()
+
+	assert.True(t, stopped)
+
+	if assert.Len(t, failures, 1) {
+		assert.True(t, errors.Is(failures[0], ErrUnknownHandlerType))
+	}
+
+	_, closed := closedTestHandlers.Load("start-1")
+	assert.True(t, closed)
+
+	l.Info("test")
+	assert.Equal(t, "test", configTestHandler("start-2").Pop().Message)
+}
+
+func TestConfigWatcher_Reload_Concurrent(t *testing.T) {
+	t.Parallel()
+	resetConfigTestHandlers("concurrent-0", "concurrent-1", "concurrent-2", "concurrent-3", "concurrent-4", "concurrent-5")
+
+	fs := newMemoryFilesystem(NewDefaultClock())
+	writeTestConfig(fs, "/etc/logger.json", `{"handler": {"type": "config_test", "options": {"id": "concurrent-0"}}}`)
+
+	watcher := NewConfigWatcher("/etc/logger.json", nil).WithFilesystem(fs)
+	l, lErr := watcher.Load()
+
+	if !assert.NoError(t, lErr) {
+		return
+	}
+
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 250; j++ {
+				l.Info("test")
+			}
+		}()
+	}
+
+	for i := 1; i <= 5; i++ {
+		writeTestConfig(fs, "/etc/logger.json", fmt.Sprintf(`{"handler": {"type": "config_test", "options": {"id": "concurrent-%d"}}}`, i))
+		assert.NoError(t, watcher.Reload())
+	}
+
+	wg.Wait()
+
+	count := 0
+
+	for i := 0; i <= 5; i++ {
+		handler := configTestHandler(fmt.Sprintf("concurrent-%d", i))
+
+		for !handler.IsEmpty() {
+			handler.Pop()
+			count++
+		}
+	}
+
+	assert.Equal(t, 1000, count)
+}
+
+func TestCloseHandler_WrappedHandlers(t *testing.T) {
+	t.Parallel()
+
+	ids := []string{"wrapped-retry", "wrapped-async", "wrapped-rate", "wrapped-sampling", "wrapped-fingers", "wrapped-shared"}
+	resetConfigTestHandlers(ids...)
+
+	handlers := make(map[string]*closableTestHandler)
+
+	for _, id := range ids {
+		handlers[id] = &closableTestHandler{InMemoryHandler: NewInMemoryHandler(0), id: id}
+	}
+
+	async := NewAsyncHandler(handlers["wrapped-async"], 10, 10, 0)
+	assert.NoError(t, async.Handle(Log{Message: "queued"}))
+
+	handler := NewMultiHandler(
+		NewRetryHandler(handlers["wrapped-retry"], 1, 0, 0).WithDeadLetterHandler(handlers["wrapped-shared"]),
+		async,
+		NewRateLimitedHandler(handlers["wrapped-rate"], nil),
+		NewSamplingHandler(handlers["wrapped-sampling"], time.Second, 1, 0),
+		NewLevelGroupedHandler(NewFingersCrossedHandler(handlers["wrapped-fingers"], LevelError, 0)),
+		handlers["wrapped-shared"],
+	)
+
+	assert.NoError(t, closeHandler(handler))
+	assert.Equal(t, "queued", handlers["wrapped-async"].Pop().Message)
+
+	for _, id := range ids {
+		_, closed := closedTestHandlers.Load(id)
+		assert.True(t, closed, id)
+	}
+
+	assert.Len(t, handlerTree(handler), 13)
+}
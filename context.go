@@ -0,0 +1,34 @@
+package logger
+
+import "context"
+
+type contextDataKey struct{}
+
+// ContextWithData returns copy of ctx which carries provided values
+// as Data. Values are merged with Data already stored in ctx and
+// must be provided in "key:value" pairs same as in standard functions.
+func ContextWithData(ctx context.Context, values ...string) context.Context {
+	d := make(Data)
+
+	for key, val := range ExtractContextData(ctx) {
+		d[key] = val
+	}
+
+	for key, val := range createDataFromSlice(values) {
+		d[key] = val
+	}
+
+	return context.WithValue(ctx, contextDataKey{}, d)
+}
+
+// ExtractContextData is ContextExtractFunc that returns Data
+// stored in ctx with ContextWithData.
+func ExtractContextData(ctx context.Context) Data {
+	d, ok := ctx.Value(contextDataKey{}).(Data)
+
+	if !ok {
+		return nil
+	}
+
+	return d
+}
@@ -0,0 +1,22 @@
+package logger
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestContextWithData(t *testing.T) {
+	t.Parallel()
+
+	ctx := ContextWithData(context.Background(), "request_id", "abc", "user_id", "42")
+	ctx = ContextWithData(ctx, "user_id", "43", "trace_id", "xyz")
+
+	assert.Equal(t, Data{"request_id": "abc", "user_id": "43", "trace_id": "xyz"}, ExtractContextData(ctx))
+}
+
+func TestExtractContextData_WithoutData(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, ExtractContextData(context.Background()))
+}
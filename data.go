@@ -1,5 +1,11 @@
 package logger
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 // Contains key:value representation
 // of data parameters provided to standard MainLogger
 // functions.
@@ -29,3 +35,33 @@ func createDataFromSlice(values []string) Data {
 
 	return res
 }
+
+// applyDataOnMessage replaces "{key}" placeholders
+// in message with values from Data.
+func applyDataOnMessage(message string, data Data) string {
+	if data.Len() < 1 {
+		return message
+	}
+
+	for key, val := range data {
+		message = strings.ReplaceAll(message, fmt.Sprintf("{%s}", key), val)
+	}
+
+	return message
+}
+
+func sortedDataKeys(data Data) []string {
+	return sortedKeys(data)
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -6,12 +6,18 @@ type ErrorWrappedLogger struct {
 	Logger
 }
 
+// stackTraceLogger is implemented by loggers which can
+// create log with stack trace extracted from error.
+type stackTraceLogger interface {
+	logWithStackTrace(level Level, message string, values []string, stack StackTrace)
+}
+
 func NewErrorWrappedLogger(logger Logger) *ErrorWrappedLogger {
 	return &ErrorWrappedLogger{Logger: logger}
 }
 
 func (w *ErrorWrappedLogger) OnError(err error, values ...string) {
-	w.on(w.Logger.Error, err, values...)
+	w.on(LevelError, w.Logger.Error, err, values...)
 }
 
 func (w *ErrorWrappedLogger) OnErrorWrapped(err error, message string, values ...interface{}) {
@@ -19,17 +25,27 @@ func (w *ErrorWrappedLogger) OnErrorWrapped(err error, message string, values ..
 }
 
 func (w *ErrorWrappedLogger) OnCritical(err error, values ...string) {
-	w.on(w.Logger.Critical, err, values...)
+	w.on(LevelCritical, w.Logger.Critical, err, values...)
 }
 
 func (w *ErrorWrappedLogger) OnCriticalWrapped(err error, message string, values ...interface{}) {
 	w.onWrapped(w.Logger.Critical, err, message, values...)
 }
 
-func (w *ErrorWrappedLogger) on(call func(message string, values ...string), err error, values ...string) {
-	if err != nil {
-		call(err.Error(), values...)
+func (w *ErrorWrappedLogger) on(level Level, call func(message string, values ...string), err error, values ...string) {
+	if err == nil {
+		return
 	}
+
+	if sl, ok := w.Logger.(stackTraceLogger); ok {
+		if stack := extractStackTrace(err); stack != nil {
+			sl.logWithStackTrace(level, err.Error(), values, stack)
+
+			return
+		}
+	}
+
+	call(err.Error(), values...)
 }
 
 func (w *ErrorWrappedLogger) onWrapped(call func(message string, values ...string), err error, message string, values ...interface{}) {
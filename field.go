@@ -0,0 +1,224 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldType describes type of value stored in Field.
+type FieldType int
+
+const (
+	FieldTypeString = FieldType(iota)
+	FieldTypeInt
+	FieldTypeFloat
+	FieldTypeBool
+	FieldTypeDuration
+	FieldTypeTime
+	FieldTypeError
+	FieldTypeAny
+	FieldTypeStringer
+	FieldTypeObject
+)
+
+type (
+	// Field is typed key:value pair attached to Log. Unlike Data
+	// it keeps original type of the value so formatters can
+	// render it natively where format supports it.
+	Field struct {
+		Key   string
+		Type  FieldType
+		Value interface{}
+	}
+	// Fields is list of typed fields attached to Log.
+	Fields []Field
+)
+
+func String(key string, val string) Field {
+	return Field{Key: key, Type: FieldTypeString, Value: val}
+}
+
+func Int(key string, val int) Field {
+	return Field{Key: key, Type: FieldTypeInt, Value: int64(val)}
+}
+
+func Int64(key string, val int64) Field {
+	return Field{Key: key, Type: FieldTypeInt, Value: val}
+}
+
+func Float(key string, val float64) Field {
+	return Field{Key: key, Type: FieldTypeFloat, Value: val}
+}
+
+func Bool(key string, val bool) Field {
+	return Field{Key: key, Type: FieldTypeBool, Value: val}
+}
+
+func Duration(key string, val time.Duration) Field {
+	return Field{Key: key, Type: FieldTypeDuration, Value: val}
+}
+
+func Time(key string, val time.Time) Field {
+	return Field{Key: key, Type: FieldTypeTime, Value: val}
+}
+
+// Err creates field with "error" key.
+func Err(err error) Field {
+	return Field{Key: "error", Type: FieldTypeError, Value: err}
+}
+
+// Any creates field with any value. Formatters supporting
+// native types (e.g. JSONFormatter) will marshal it.
+func Any(key string, val interface{}) Field {
+	return Field{Key: key, Type: FieldTypeAny, Value: val}
+}
+
+func Stringer(key string, val fmt.Stringer) Field {
+	return Field{Key: key, Type: FieldTypeStringer, Value: val}
+}
+
+// Object creates field with nested fields.
+func Object(key string, fields ...Field) Field {
+	return Field{Key: key, Type: FieldTypeObject, Value: Fields(fields)}
+}
+
+// String returns text representation of field value. Values with
+// type other than expected by field type are formatted with fmt.
+func (f Field) String() string {
+	switch val := f.Value.(type) {
+	case string:
+		if f.Type == FieldTypeString {
+			return val
+		}
+	case int64:
+		if f.Type == FieldTypeInt {
+			return strconv.FormatInt(val, 10)
+		}
+	case float64:
+		if f.Type == FieldTypeFloat {
+			return strconv.FormatFloat(val, 'g', -1, 64)
+		}
+	case time.Time:
+		if f.Type == FieldTypeTime {
+			return val.Format(time.RFC3339Nano)
+		}
+	case Fields:
+		if f.Type == FieldTypeObject {
+			return val.String()
+		}
+	}
+
+	// fmt handles nil values and typed nil pointers
+	// of error and fmt.Stringer without panic.
+	return fmt.Sprintf("%v", f.Value)
+}
+
+// String returns text representation of fields in "{key:value key2:value2}" format.
+func (fs Fields) String() string {
+	res := &strings.Builder{}
+	res.WriteString("{")
+
+	for idx, field := range fs {
+		if idx > 0 {
+			res.WriteString(" ")
+		}
+
+		res.WriteString(field.Key)
+		res.WriteString(":")
+		res.WriteString(field.String())
+	}
+
+	res.WriteString("}")
+
+	return res.String()
+}
+
+// Data converts fields to Data with text representation of values.
+func (fs Fields) Data() Data {
+	d := make(Data)
+
+	for _, field := range fs {
+		d[field.Key] = field.String()
+	}
+
+	return d
+}
+
+// withFields returns copy of Data extended with text representation
+// of fields. Fields take precedence over Data values with the same keys.
+func (d Data) withFields(fields Fields) Data {
+	if len(fields) == 0 {
+		return d
+	}
+
+	res := make(Data)
+
+	for key, val := range d {
+		res[key] = val
+	}
+
+	for _, field := range fields {
+		res[field.Key] = field.String()
+	}
+
+	return res
+}
+
+// marshalJSON returns JSON representation of field value
+// using native JSON types where possible.
+func (f Field) marshalJSON() string {
+	switch f.Type {
+	case FieldTypeInt, FieldTypeBool, FieldTypeAny:
+		return f.marshalValue()
+	case FieldTypeFloat:
+		if _, ok := f.Value.(float64); !ok {
+			return f.marshalValue()
+		}
+
+		if _, err := json.Marshal(f.Value); err != nil {
+			return quoteJSON(f.String())
+		}
+
+		return f.String()
+	case FieldTypeObject:
+		fields, ok := f.Value.(Fields)
+
+		if !ok {
+			return f.marshalValue()
+		}
+
+		res := &strings.Builder{}
+		res.WriteString("{")
+
+		for idx, field := range fields {
+			if idx > 0 {
+				res.WriteString(",")
+			}
+
+			res.WriteString(quoteJSON(field.Key))
+			res.WriteString(":")
+			res.WriteString(field.marshalJSON())
+		}
+
+		res.WriteString("}")
+
+		return res.String()
+	default:
+		return quoteJSON(f.String())
+	}
+}
+
+// marshalValue marshals field value with encoding/json
+// and falls back to its text representation.
+func (f Field) marshalValue() string {
+	val, err := json.Marshal(f.Value)
+
+	if err != nil {
+		return quoteJSON(f.String())
+	}
+
+	return string(val)
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestField_String(t *testing.T) {
+	t.Parallel()
+
+	tm := time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC)
+
+	assert.Equal(t, "val", String("key", "val").String())
+	assert.Equal(t, "-42", Int("key", -42).String())
+	assert.Equal(t, "42", Int64("key", 42).String())
+	assert.Equal(t, "1.5", Float("key", 1.5).String())
+	assert.Equal(t, "true", Bool("key", true).String())
+	assert.Equal(t, "1.5s", Duration("key", 1500*time.Millisecond).String())
+	assert.Equal(t, "2020-08-25T19:06:36Z", Time("key", tm).String())
+	assert.Equal(t, "test", Err(errors.New("test")).String())
+	assert.Equal(t, "<nil>", Err(nil).String())
+	assert.Equal(t, "[1 2]", Any("key", []int{1, 2}).String())
+	assert.Equal(t, "127.0.0.1", Stringer("key", net.IPv4(127, 0, 0, 1)).String())
+	assert.Equal(t, "{a:1 b:x}", Object("key", Int("a", 1), String("b", "x")).String())
+}
+
+func TestField_String_UnexpectedValue(t *testing.T) {
+	t.Parallel()
+
+	var u *url.URL
+
+	assert.Equal(t, "5", Field{Key: "key", Type: FieldTypeInt, Value: 5}.String())
+	assert.Equal(t, "x", Field{Key: "key", Type: FieldTypeFloat, Value: "x"}.String())
+	assert.Equal(t, "<nil>", Field{Key: "key", Type: FieldTypeTime}.String())
+	assert.Equal(t, "[]", Field{Key: "key", Type: FieldTypeObject, Value: []Field{}}.String())
+	assert.Equal(t, "<nil>", Stringer("key", u).String())
+}
+
+func TestField_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `"val"`, String("key", "val").marshalJSON())
+	assert.Equal(t, `42`, Int("key", 42).marshalJSON())
+	assert.Equal(t, `1.5`, Float("key", 1.5).marshalJSON())
+	assert.Equal(t, `"NaN"`, Float("key", math.NaN()).marshalJSON())
+	assert.Equal(t, `false`, Bool("key", false).marshalJSON())
+	assert.Equal(t, `"1m0s"`, Duration("key", time.Minute).marshalJSON())
+	assert.Equal(t, `"test"`, Err(errors.New("test")).marshalJSON())
+	assert.Equal(t, `[1,2]`, Any("key", []int{1, 2}).marshalJSON())
+	assert.Equal(t, `{"a":1,"b":{"c":true}}`, Object("key", Int("a", 1), Object("b", Bool("c", true))).marshalJSON())
+}
+
+func TestField_MarshalJSON_UnexpectedValue(t *testing.T) {
+	t.Parallel()
+
+	var u *url.URL
+
+	assert.Equal(t, `5`, Field{Key: "key", Type: FieldTypeInt, Value: 5}.marshalJSON())
+	assert.Equal(t, `"x"`, Field{Key: "key", Type: FieldTypeBool, Value: "x"}.marshalJSON())
+	assert.Equal(t, `1.5`, Field{Key: "key", Type: FieldTypeFloat, Value: float32(1.5)}.marshalJSON())
+	assert.Equal(t, `null`, Field{Key: "key", Type: FieldTypeObject}.marshalJSON())
+	assert.Equal(t, `"<nil>"`, Stringer("key", u).marshalJSON())
+}
+
+func TestFields_Data(t *testing.T) {
+	t.Parallel()
+
+	fields := Fields{String("key", "val"), Int("answer", 42)}
+
+	assert.Equal(t, Data{"key": "val", "answer": "42"}, fields.Data())
+}
+
+func TestMainLogger_LogWithFields(t *testing.T) {
+	t.Parallel()
+
+	fields := []Field{String("key", "val"), Int("answer", 42)}
+	levels := map[Level]func(logger *MainLogger){
+		LevelDebug: func(logger *MainLogger) {
+			logger.Debugw("test message", fields...)
+		},
+		LevelInfo: func(logger *MainLogger) {
+			logger.Infow("test message", fields...)
+		},
+		LevelWarning: func(logger *MainLogger) {
+			logger.Warningw("test message", fields...)
+		},
+		LevelError: func(logger *MainLogger) {
+			logger.Errorw("test message", fields...)
+		},
+		LevelCritical: func(logger *MainLogger) {
+			logger.Criticalw("test message", fields...)
+		},
+	}
+
+	for level, callback := range levels {
+		tm := time.Now()
+
+		mClock := &mockClock{}
+		mClock.On("Now").Return(tm)
+
+		mHandler := &mockHandler{}
+		mHandler.On("Handle", Log{
+			Level:     level,
+			Message:   "test message",
+			Data:      Data{"request_id": "abc"},
+			Fields:    fields,
+			CreatedAt: tm,
+		}).Return(nil)
+
+		logger := New(mHandler)
+		logger.WithClock(mClock)
+
+		callback(logger.With("request_id", "abc"))
+
+		mClock.AssertExpectations(t)
+		mHandler.AssertExpectations(t)
+	}
+}
@@ -98,11 +98,38 @@ func (f *FileHandler) Close() error {
 	f.fileLock.Lock()
 	defer f.fileLock.Unlock()
 
+	return f.closeFile()
+}
+
+// Reopen closes current log file and opens file at handler path again.
+// It should be used when file was moved (e.g. by logrotate), otherwise
+// handler keeps writing to moved file.
+func (f *FileHandler) Reopen() error {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+
+	cErr := f.closeFile()
+
+	if cErr != nil {
+		return cErr
+	}
+
+	return f.openFile()
+}
+
+func (f *FileHandler) closeFile() error {
 	if f.file == nil {
 		return nil
 	}
 
-	return f.file.Close()
+	cErr := f.file.Close()
+	f.file = nil
+
+	if cErr != nil {
+		return fmt.Errorf("unable to close log file: %w", cErr)
+	}
+
+	return nil
 }
 
 func (f *FileHandler) openFile() error {
@@ -234,3 +234,32 @@ func TestFileHandler_HandleBatch(t *testing.T) {
 		f.AssertNumberOfCalls(t, "Write", 100)
 	})
 }
+
+func TestFileHandler_Reopen(t *testing.T) {
+	t.Parallel()
+
+	f1 := &mockFile{}
+	f1.On("WriteString", "first\n").Return(6, nil)
+	f1.On("Close").Return(nil).Once()
+
+	f2 := &mockFile{}
+	f2.On("WriteString", "second\n").Return(7, nil)
+	f2.On("Close").Return(nil).Once()
+
+	fs := &mockFilesystem{}
+	fs.On("OpenFile", "/path/to/log.file", os.O_CREATE|os.O_WRONLY|os.O_APPEND, os.FileMode(0644)).Return(f1, nil).Once()
+	fs.On("OpenFile", "/path/to/log.file", os.O_CREATE|os.O_WRONLY|os.O_APPEND, os.FileMode(0644)).Return(f2, nil).Once()
+
+	fh := NewFileHandler("/path/to/log.file")
+	fh.WithFilesystem(fs)
+
+	assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "first", Data: make(Data), CreatedAt: time.Now()}))
+	assert.NoError(t, fh.Reopen())
+	assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "second", Data: make(Data), CreatedAt: time.Now()}))
+	assert.NoError(t, fh.Close())
+	assert.NoError(t, fh.Close())
+
+	f1.AssertExpectations(t)
+	f2.AssertExpectations(t)
+	fs.AssertExpectations(t)
+}
@@ -2,6 +2,7 @@ package logger
 
 import (
 	"io"
+	"io/ioutil"
 	"os"
 )
 
@@ -10,9 +11,15 @@ type (
 		io.StringWriter
 		io.Writer
 		io.Closer
+		Sync() error
 	}
 	Filesystem interface {
+		Open(name string) (io.ReadCloser, error)
 		OpenFile(name string, flag int, perm os.FileMode) (File, error)
+		Rename(oldPath, newPath string) error
+		Stat(name string) (os.FileInfo, error)
+		Remove(name string) error
+		ReadDir(dirname string) ([]os.FileInfo, error)
 	}
 	DefaultFilesystem struct {
 	}
@@ -22,6 +29,26 @@ func NewDefaultFilesystem() *DefaultFilesystem {
 	return &DefaultFilesystem{}
 }
 
+func (fs *DefaultFilesystem) Open(name string) (io.ReadCloser, error) {
+	return os.Open(name)
+}
+
 func (fs *DefaultFilesystem) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
 	return os.OpenFile(name, flag, perm)
 }
+
+func (fs *DefaultFilesystem) Rename(oldPath, newPath string) error {
+	return os.Rename(oldPath, newPath)
+}
+
+func (fs *DefaultFilesystem) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (fs *DefaultFilesystem) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (fs *DefaultFilesystem) ReadDir(dirname string) ([]os.FileInfo, error) {
+	return ioutil.ReadDir(dirname)
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+)
+
+// FingersCrossedHandler buffers all logs of a scope (e.g. single request)
+// in memory and passes them to wrapped handler only when log with
+// activation level (or above) arrives. Buffered logs are discarded
+// when scope ends (Reset) without activation. When buffer is full,
+// the oldest logs are overwritten.
+type FingersCrossedHandler struct {
+	handler         Handler
+	activationLevel Level
+	bufferSize      uint
+	passThrough     bool
+	lock            sync.Mutex
+	buffer          []Log
+	start           int
+	activated       bool
+}
+
+// NewFingersCrossedHandler creates FingersCrossedHandler which keeps
+// up to "bufferSize" logs (zero - no limit) until log with
+// "activationLevel" or above arrives.
+func NewFingersCrossedHandler(handler Handler, activationLevel Level, bufferSize uint) *FingersCrossedHandler {
+	return &FingersCrossedHandler{handler: handler, activationLevel: activationLevel, bufferSize: bufferSize}
+}
+
+// WithPassThrough makes handler pass all logs directly to wrapped
+// handler after activation until scope ends. By default handler
+// starts buffering again after activation.
+func (h *FingersCrossedHandler) WithPassThrough() *FingersCrossedHandler {
+	h.passThrough = true
+
+	return h
+}
+
+func (h *FingersCrossedHandler) Handle(log Log) error {
+	return h.HandleBatch([]Log{log})
+}
+
+func (h *FingersCrossedHandler) HandleBatch(logs []Log) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.activated && h.passThrough {
+		return h.forward(logs)
+	}
+
+	activate := false
+
+	for _, log := range logs {
+		h.push(log)
+
+		if log.Level.EqualOrGreaterThan(h.activationLevel) {
+			activate = true
+		}
+	}
+
+	if !activate {
+		return nil
+	}
+
+	h.activated = true
+	buffered := h.drain()
+
+	return h.forward(buffered)
+}
+
+// IsActivated returns true if log with activation level arrived in current scope.
+func (h *FingersCrossedHandler) IsActivated() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return h.activated
+}
+
+// Reset ends current scope. All buffered logs are discarded
+// and handler is no longer activated.
+func (h *FingersCrossedHandler) Reset() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.buffer = nil
+	h.start = 0
+	h.activated = false
+}
+
+func (h *FingersCrossedHandler) forward(logs []Log) error {
+	err := h.handler.HandleBatch(logs)
+
+	if err != nil {
+		return fmt.Errorf("FingersCrossedHandler - wrapped handler returned an error: %w", err)
+	}
+
+	return nil
+}
+
+func (h *FingersCrossedHandler) push(log Log) {
+	if h.bufferSize == 0 || uint(len(h.buffer)) < h.bufferSize {
+		h.buffer = append(h.buffer, log)
+
+		return
+	}
+
+	h.buffer[h.start] = log
+	h.start = (h.start + 1) % len(h.buffer)
+}
+
+// drain returns buffered logs from the oldest one and clears buffer.
+func (h *FingersCrossedHandler) drain() []Log {
+	logs := append(h.buffer[h.start:len(h.buffer):len(h.buffer)], h.buffer[:h.start]...)
+	h.buffer = nil
+	h.start = 0
+
+	return logs
+}
+
+func (h *FingersCrossedHandler) children() []Handler {
+	return []Handler{h.handler}
+}
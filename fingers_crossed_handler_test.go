@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestFingersCrossedHandler_Handle(t *testing.T) {
+	t.Parallel()
+
+	logs := []Log{
+		{Level: LevelDebug, Message: "debug", Data: make(Data), CreatedAt: time.Now()},
+		{Level: LevelInfo, Message: "info", Data: make(Data), CreatedAt: time.Now()},
+		{Level: LevelError, Message: "error", Data: make(Data), CreatedAt: time.Now()},
+		{Level: LevelDebug, Message: "debug 2", Data: make(Data), CreatedAt: time.Now()},
+	}
+
+	mHandler := &mockHandler{}
+	mHandler.On("HandleBatch", logs[:3]).Return(nil).Once()
+
+	handler := NewFingersCrossedHandler(mHandler, LevelError, 0)
+
+	assert.NoError(t, handler.Handle(logs[0]))
+	assert.NoError(t, handler.Handle(logs[1]))
+	assert.False(t, handler.IsActivated())
+
+	assert.NoError(t, handler.Handle(logs[2]))
+	assert.True(t, handler.IsActivated())
+
+	assert.NoError(t, handler.Handle(logs[3]))
+
+	mHandler.AssertExpectations(t)
+	mHandler.AssertNumberOfCalls(t, "HandleBatch", 1)
+}
+
+func TestFingersCrossedHandler_Reset(t *testing.T) {
+	t.Parallel()
+
+	mHandler := &mockHandler{}
+	handler := NewFingersCrossedHandler(mHandler, LevelError, 0)
+
+	assert.NoError(t, handler.Handle(Log{Level: LevelDebug, Message: "debug"}))
+	handler.Reset()
+
+	mHandler.On("HandleBatch", []Log{{Level: LevelCritical, Message: "critical"}}).Return(nil).Once()
+	assert.NoError(t, handler.Handle(Log{Level: LevelCritical, Message: "critical"}))
+	assert.True(t, handler.IsActivated())
+
+	handler.Reset()
+	assert.False(t, handler.IsActivated())
+
+	mHandler.AssertExpectations(t)
+}
+
+func TestFingersCrossedHandler_WithPassThrough(t *testing.T) {
+	t.Parallel()
+
+	memory := NewInMemoryHandler(0)
+	handler := NewFingersCrossedHandler(memory, LevelWarning, 0).WithPassThrough()
+
+	assert.NoError(t, handler.HandleBatch([]Log{{Level: LevelInfo, Message: "info"}, {Level: LevelWarning, Message: "warning"}}))
+	assert.NoError(t, handler.Handle(Log{Level: LevelDebug, Message: "debug"}))
+
+	assert.Equal(t, "debug", memory.Pop().Message)
+	assert.Equal(t, "warning", memory.Pop().Message)
+	assert.Equal(t, "info", memory.Pop().Message)
+	assert.True(t, memory.IsEmpty())
+}
+
+func TestFingersCrossedHandler_BufferSize(t *testing.T) {
+	t.Parallel()
+
+	memory := NewInMemoryHandler(0)
+	handler := NewFingersCrossedHandler(memory, LevelError, 3)
+
+	for _, message := range []string{"1", "2", "3", "4", "5"} {
+		assert.NoError(t, handler.Handle(Log{Level: LevelDebug, Message: message}))
+	}
+
+	assert.NoError(t, handler.Handle(Log{Level: LevelError, Message: "error"}))
+
+	assert.Equal(t, "error", memory.Pop().Message)
+	assert.Equal(t, "5", memory.Pop().Message)
+	assert.Equal(t, "4", memory.Pop().Message)
+	assert.True(t, memory.IsEmpty())
+}
+
+func TestFingersCrossedHandler_Handle_Failure(t *testing.T) {
+	t.Parallel()
+
+	mHandler := &mockHandler{}
+	mHandler.On("HandleBatch", mock.Anything).Return(errors.New("test"))
+
+	err := NewFingersCrossedHandler(mHandler, LevelError, 0).Handle(Log{Level: LevelError, Message: "error"})
+
+	if assert.Error(t, err) {
+		assert.EqualError(t, err, "FingersCrossedHandler - wrapped handler returned an error: test")
+	}
+}
@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// GzipCompressor compresses files using gzip format.
+type GzipCompressor struct {
+	level int
+}
+
+// NewGzipCompressor creates GzipCompressor with provided
+// compression level (e.g. gzip.DefaultCompression).
+func NewGzipCompressor(level int) *GzipCompressor {
+	return &GzipCompressor{level: level}
+}
+
+func (c *GzipCompressor) Compress(dst io.Writer, src io.Reader) error {
+	writer, wErr := gzip.NewWriterLevel(dst, c.level)
+
+	if wErr != nil {
+		return fmt.Errorf("GzipCompressor - unable to create writer: %w", wErr)
+	}
+
+	_, cErr := io.Copy(writer, src)
+
+	if cErr != nil {
+		return fmt.Errorf("GzipCompressor - unable to compress data: %w", cErr)
+	}
+
+	clErr := writer.Close()
+
+	if clErr != nil {
+		return fmt.Errorf("GzipCompressor - unable to compress data: %w", clErr)
+	}
+
+	return nil
+}
+
+func (c *GzipCompressor) Extension() string {
+	return ".gz"
+}
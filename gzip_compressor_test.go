@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestGzipCompressor_Compress(t *testing.T) {
+	t.Parallel()
+
+	dst := &bytes.Buffer{}
+	compressor := NewGzipCompressor(gzip.BestCompression)
+
+	err := compressor.Compress(dst, strings.NewReader("test message\ntest message 2\n"))
+
+	if assert.NoError(t, err) {
+		reader, rErr := gzip.NewReader(dst)
+
+		if assert.NoError(t, rErr) {
+			content, _ := ioutil.ReadAll(reader)
+			assert.Equal(t, "test message\ntest message 2\n", string(content))
+		}
+	}
+}
+
+func TestGzipCompressor_Compress_InvalidLevel(t *testing.T) {
+	t.Parallel()
+
+	compressor := NewGzipCompressor(42)
+	err := compressor.Compress(&bytes.Buffer{}, strings.NewReader("test"))
+
+	if assert.Error(t, err) {
+		assert.EqualError(t, err, "GzipCompressor - unable to create writer: gzip: invalid compression level: 42")
+	}
+}
+
+func TestGzipCompressor_Extension(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ".gz", NewGzipCompressor(gzip.DefaultCompression).Extension())
+}
@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+)
+
+// HandlerFailure describes failure of handler. MainLogger always passes
+// *HandlerFailure as error to FailureHandleFunc and handlers grouping other
+// handlers (LevelGroupedHandler, MultiHandler) wrap errors of their
+// handlers with it, so it can be extracted with errors.As.
+type HandlerFailure struct {
+	// Handler is name of the failed handler or of handler
+	// grouping it (e.g. "MultiHandler").
+	Handler string
+	// Index of failed handler inside grouping handler
+	// (-1 for fallback or not grouped handler).
+	Index int
+	// Attempts is number of attempts made to handle log.
+	Attempts int
+	// Dropped is true if log was not handled by any handler (including fallback handler).
+	// It is false when other handler from the same group handled the log.
+	Dropped bool
+	Err     error
+}
+
+func (f *HandlerFailure) Error() string {
+	return f.Err.Error()
+}
+
+func (f *HandlerFailure) Unwrap() error {
+	return f.Err
+}
+
+// innermost returns the deepest HandlerFailure wrapped by failure
+// (e.g. failure of RetryHandler nested in MultiHandler).
+func (f *HandlerFailure) innermost() *HandlerFailure {
+	res := f
+	next := &HandlerFailure{}
+
+	for errors.As(res.Err, &next) {
+		res = next
+	}
+
+	return res
+}
+
+// isIntentionalDrop checks if all errors in the tree are caused by logs
+// dropped on purpose (ErrRateLimited, ErrAsyncHandlerClosed), which should
+// not be passed to fallback handler.
+func isIntentionalDrop(err error) bool {
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		errs := e.Unwrap()
+
+		for _, child := range errs {
+			if !isIntentionalDrop(child) {
+				return false
+			}
+		}
+
+		return len(errs) > 0
+	case interface{ Unwrap() error }:
+		return isIntentionalDrop(e.Unwrap())
+	default:
+		// errors wrapping other errors are checked above, so errors.Is
+		// cannot match sentinel wrapped together with other errors
+		return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrAsyncHandlerClosed)
+	}
+}
+
+// handlerName returns name of handler used in HandlerFailure.
+func handlerName(handler Handler) string {
+	if r, ok := handler.(*reloadableHandler); ok {
+		return handlerName(r.current())
+	}
+
+	return fmt.Sprintf("%T", handler)
+}
@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHandlerFailure(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("test")
+	var failure error = &HandlerFailure{Handler: "MultiHandler", Index: 1, Attempts: 1, Err: err}
+
+	assert.EqualError(t, failure, "test")
+	assert.True(t, errors.Is(failure, err))
+}
+
+func TestIsIntentionalDrop(t *testing.T) {
+	t.Parallel()
+
+	rateLimited := fmt.Errorf("%w (%d logs)", ErrRateLimited, 2)
+
+	assert.True(t, isIntentionalDrop(ErrRateLimited))
+	assert.True(t, isIntentionalDrop(rateLimited))
+	assert.True(t, isIntentionalDrop(&HandlerFailure{Err: ErrAsyncHandlerClosed}))
+	assert.True(t, isIntentionalDrop(errors.Join(rateLimited, &RateLimitedError{})))
+	assert.False(t, isIntentionalDrop(ErrAsyncHandlerOverflow))
+	assert.False(t, isIntentionalDrop(errors.Join(rateLimited, errors.New("disk full"))))
+	assert.False(t, isIntentionalDrop(errors.New("disk full")))
+}
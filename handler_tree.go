@@ -0,0 +1,47 @@
+package logger
+
+import "reflect"
+
+// handlerGroup is implemented by handlers grouping or wrapping other
+// handlers, so whole handler tree can be walked (e.g. to close or reopen files).
+type handlerGroup interface {
+	children() []Handler
+}
+
+// handlerTree returns handlers and all handlers grouped or wrapped by them
+// (recursively). Parent handlers are returned before their children and
+// handler used in many places of the trees is returned only once.
+func handlerTree(handlers ...Handler) []Handler {
+	var res []Handler
+
+	seen := make(map[Handler]bool)
+
+	var walk func(h Handler)
+	walk = func(h Handler) {
+		if h == nil {
+			return
+		}
+
+		if reflect.TypeOf(h).Comparable() {
+			if seen[h] {
+				return
+			}
+
+			seen[h] = true
+		}
+
+		res = append(res, h)
+
+		if group, ok := h.(handlerGroup); ok {
+			for _, child := range group.children() {
+				walk(child)
+			}
+		}
+	}
+
+	for _, handler := range handlers {
+		walk(handler)
+	}
+
+	return res
+}
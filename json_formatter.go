@@ -0,0 +1,255 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeLayoutUnixMillis can be used as time layout in formatters
+// to present log time as number of milliseconds since Unix epoch.
+const TimeLayoutUnixMillis = "unix_millis"
+
+// JSONFormatter creates single-line JSON object from Log.
+type JSONFormatter struct {
+	timeKey          string
+	levelKey         string
+	messageKey       string
+	nameKey          string
+	dataKey          string
+	callerKey        string
+	stackKey         string
+	timeLayout       string
+	unknownLevelName string
+}
+
+// NewJSONFormatter creates JSONFormatter with "time", "level", "message"
+// and "data" keys and time.RFC3339Nano time layout.
+func NewJSONFormatter() *JSONFormatter {
+	return &JSONFormatter{
+		timeKey:          "time",
+		levelKey:         "level",
+		messageKey:       "message",
+		nameKey:          "logger",
+		dataKey:          "data",
+		callerKey:        "caller",
+		stackKey:         "stack",
+		timeLayout:       time.RFC3339Nano,
+		unknownLevelName: "unknown",
+	}
+}
+
+// WithKeys changes keys used for log time, level and message.
+func (f *JSONFormatter) WithKeys(timeKey string, levelKey string, messageKey string) *JSONFormatter {
+	f.timeKey = timeKey
+	f.levelKey = levelKey
+	f.messageKey = messageKey
+
+	return f
+}
+
+// WithDataKey changes key under which Data is nested. Empty key
+// flattens Data to the top level of the object (keys colliding
+// with time, level, message, name, caller or stack keys are prefixed with "data.").
+func (f *JSONFormatter) WithDataKey(dataKey string) *JSONFormatter {
+	f.dataKey = dataKey
+
+	return f
+}
+
+// WithNameKey changes key under which Log.Name is presented ("logger" by default).
+func (f *JSONFormatter) WithNameKey(nameKey string) *JSONFormatter {
+	f.nameKey = nameKey
+
+	return f
+}
+
+// WithCallerKey changes key under which Log.Caller is presented ("caller" by default).
+func (f *JSONFormatter) WithCallerKey(callerKey string) *JSONFormatter {
+	f.callerKey = callerKey
+
+	return f
+}
+
+// WithStackKey changes key under which Log.Stack is presented ("stack" by default).
+func (f *JSONFormatter) WithStackKey(stackKey string) *JSONFormatter {
+	f.stackKey = stackKey
+
+	return f
+}
+
+// WithTimeLayout changes layout used for log time. TimeLayoutUnixMillis
+// presents time as number instead of string.
+func (f *JSONFormatter) WithTimeLayout(layout string) *JSONFormatter {
+	f.timeLayout = layout
+
+	return f
+}
+
+// WithUnknownLevelName changes level name used for levels without mapping ("unknown" by default).
+func (f *JSONFormatter) WithUnknownLevelName(name string) *JSONFormatter {
+	f.unknownLevelName = name
+
+	return f
+}
+
+func (f *JSONFormatter) Format(log Log) FormattedLog {
+	return FormattedLog{
+		Log:              log,
+		FormattedMessage: f.createFormattedMessage(log),
+	}
+}
+
+func (f *JSONFormatter) createFormattedMessage(log Log) string {
+	ln, lnErr := log.Level.Name()
+
+	if errors.Is(lnErr, ErrLevelNameMappingNotFound) {
+		ln = LevelName(f.unknownLevelName)
+	}
+
+	res := &bytes.Buffer{}
+	res.WriteString("{")
+
+	if f.timeLayout == TimeLayoutUnixMillis {
+		f.writeRawField(res, f.timeKey, strconv.FormatInt(log.CreatedAt.UnixNano()/1e6, 10))
+	} else {
+		f.writeField(res, f.timeKey, log.CreatedAt.Format(f.timeLayout))
+	}
+
+	f.writeField(res, f.levelKey, ln.String())
+
+	if log.Name != "" {
+		f.writeField(res, f.nameKey, log.Name)
+	}
+
+	f.writeField(res, f.messageKey, applyDataOnMessage(log.Message, log.Data.withFields(log.Fields)))
+
+	if log.Caller != nil {
+		f.writeKey(res, f.callerKey)
+		f.writeCaller(res, *log.Caller)
+	}
+
+	if len(log.Stack) > 0 {
+		f.writeKey(res, f.stackKey)
+		res.WriteString("[")
+
+		for idx, frame := range log.Stack {
+			if idx > 0 {
+				res.WriteString(",")
+			}
+
+			f.writeCaller(res, frame)
+		}
+
+		res.WriteString("]")
+	}
+
+	if log.Data.Len() > 0 || len(log.Fields) > 0 {
+		values := f.createDataValues(log.Data, log.Fields)
+
+		if f.dataKey != "" {
+			f.writeKey(res, f.dataKey)
+			f.writeData(res, values)
+		} else {
+			f.writeFlattenedData(res, values)
+		}
+	}
+
+	res.WriteString("}")
+
+	return res.String()
+}
+
+// createDataValues returns JSON representation of Data and Fields values.
+// Fields are rendered with native JSON types and take precedence over Data.
+func (f *JSONFormatter) createDataValues(data Data, fields Fields) map[string]string {
+	values := make(map[string]string)
+
+	for key, val := range data {
+		values[key] = quoteJSON(val)
+	}
+
+	for _, field := range fields {
+		values[field.Key] = field.marshalJSON()
+	}
+
+	return values
+}
+
+func (f *JSONFormatter) writeFlattenedData(res *bytes.Buffer, values map[string]string) {
+	for _, key := range sortedKeys(values) {
+		name := key
+
+		if key == f.timeKey || key == f.levelKey || key == f.messageKey || key == f.nameKey ||
+			key == f.callerKey || key == f.stackKey {
+			name = fmt.Sprintf("data.%s", key)
+		}
+
+		f.writeRawField(res, name, values[key])
+	}
+}
+
+func (f *JSONFormatter) writeData(res *bytes.Buffer, values map[string]string) {
+	res.WriteString("{")
+
+	for idx, key := range sortedKeys(values) {
+		if idx > 0 {
+			res.WriteString(",")
+		}
+
+		writeJSONString(res, key)
+		res.WriteString(":")
+		res.WriteString(values[key])
+	}
+
+	res.WriteString("}")
+}
+
+func (f *JSONFormatter) writeCaller(res *bytes.Buffer, caller Caller) {
+	res.WriteString(`{"file":`)
+	writeJSONString(res, caller.File)
+	res.WriteString(`,"line":`)
+	res.WriteString(strconv.Itoa(caller.Line))
+	res.WriteString(`,"function":`)
+	writeJSONString(res, caller.Function)
+	res.WriteString("}")
+}
+
+func (f *JSONFormatter) writeField(res *bytes.Buffer, key string, value string) {
+	f.writeKey(res, key)
+	writeJSONString(res, value)
+}
+
+func (f *JSONFormatter) writeRawField(res *bytes.Buffer, key string, value string) {
+	f.writeKey(res, key)
+	res.WriteString(value)
+}
+
+func (f *JSONFormatter) writeKey(res *bytes.Buffer, key string) {
+	if res.Len() > 1 {
+		res.WriteString(",")
+	}
+
+	writeJSONString(res, key)
+	res.WriteString(":")
+}
+
+func writeJSONString(res *bytes.Buffer, s string) {
+	res.WriteString(quoteJSON(s))
+}
+
+// quoteJSON returns s as quoted JSON string
+// without escaping HTML characters.
+func quoteJSON(s string) string {
+	buff := &bytes.Buffer{}
+	encoder := json.NewEncoder(buff)
+	encoder.SetEscapeHTML(false)
+
+	_ = encoder.Encode(s)
+
+	return strings.TrimSuffix(buff.String(), "\n")
+}
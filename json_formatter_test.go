@@ -0,0 +1,192 @@
+package logger
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestJSONFormatter_Format(t *testing.T) {
+	t.Parallel()
+
+	tm := time.Date(2020, 8, 25, 19, 6, 36, 123456789, time.UTC)
+
+	t.Run("without additional data", func(t *testing.T) {
+		formatter := NewJSONFormatter()
+		formatted := formatter.Format(Log{
+			Level:     LevelDebug,
+			Message:   "test message",
+			Data:      Data{},
+			CreatedAt: tm,
+		})
+
+		assert.IsType(t, FormattedLog{}, formatted)
+		assert.Equal(t, `{"time":"2020-08-25T19:06:36.123456789Z","level":"debug","message":"test message"}`, formatted.FormattedMessage)
+	})
+
+	t.Run("with nested data", func(t *testing.T) {
+		formatter := NewJSONFormatter()
+		formatted := formatter.Format(Log{
+			Level:     LevelInfo,
+			Message:   "test message {answer}",
+			Data:      Data{"key": "val", "answer": "42"},
+			CreatedAt: tm,
+		})
+
+		assert.Equal(
+			t,
+			`{"time":"2020-08-25T19:06:36.123456789Z","level":"info","message":"test message 42","data":{"answer":"42","key":"val"}}`,
+			formatted.FormattedMessage,
+		)
+	})
+
+	t.Run("with flattened data", func(t *testing.T) {
+		formatter := NewJSONFormatter().WithDataKey("")
+		formatted := formatter.Format(Log{
+			Level:     LevelInfo,
+			Message:   "test message",
+			Data:      Data{"key": "val", "level": "42"},
+			CreatedAt: tm,
+		})
+
+		assert.Equal(
+			t,
+			`{"time":"2020-08-25T19:06:36.123456789Z","level":"info","message":"test message","key":"val","data.level":"42"}`,
+			formatted.FormattedMessage,
+		)
+	})
+
+	t.Run("with custom keys and unix millis", func(t *testing.T) {
+		formatter := NewJSONFormatter().WithKeys("ts", "lvl", "msg").WithTimeLayout(TimeLayoutUnixMillis)
+		formatted := formatter.Format(Log{
+			Level:     LevelError,
+			Message:   "test message",
+			Data:      Data{},
+			CreatedAt: tm,
+		})
+
+		assert.Equal(t, `{"ts":1598382396123,"lvl":"error","msg":"test message"}`, formatted.FormattedMessage)
+	})
+
+	t.Run("with logger name", func(t *testing.T) {
+		formatter := NewJSONFormatter().WithNameKey("component").WithDataKey("").WithTimeLayout(time.RFC3339)
+		formatted := formatter.Format(Log{
+			Level:     LevelInfo,
+			Name:      "db.pool",
+			Message:   "test message",
+			Data:      Data{"component": "val"},
+			CreatedAt: tm,
+		})
+
+		assert.Equal(
+			t,
+			`{"time":"2020-08-25T19:06:36Z","level":"info","component":"db.pool","message":"test message","data.component":"val"}`,
+			formatted.FormattedMessage,
+		)
+	})
+
+	t.Run("with unknown level", func(t *testing.T) {
+		formatter := NewJSONFormatter().WithUnknownLevelName("custom").WithTimeLayout(time.RFC3339)
+		formatted := formatter.Format(Log{
+			Level:     Level(5000),
+			Message:   "test message",
+			Data:      Data{},
+			CreatedAt: tm,
+		})
+
+		assert.Equal(t, `{"time":"2020-08-25T19:06:36Z","level":"custom","message":"test message"}`, formatted.FormattedMessage)
+	})
+
+	t.Run("with caller", func(t *testing.T) {
+		formatter := NewJSONFormatter().WithCallerKey("src")
+		formatted := formatter.Format(Log{
+			Level:     LevelInfo,
+			Message:   "test message",
+			Data:      Data{},
+			CreatedAt: tm,
+			Caller:    &Caller{File: "/app/main.go", Line: 42, Function: "main.main"},
+		})
+
+		assert.Equal(
+			t,
+			`{"time":"2020-08-25T19:06:36.123456789Z","level":"info","message":"test message","src":{"file":"/app/main.go","line":42,"function":"main.main"}}`,
+			formatted.FormattedMessage,
+		)
+	})
+
+	t.Run("with stack trace", func(t *testing.T) {
+		formatter := NewJSONFormatter()
+		formatted := formatter.Format(Log{
+			Level:     LevelError,
+			Message:   "test message",
+			Data:      Data{},
+			CreatedAt: tm,
+			Stack: StackTrace{
+				{File: "/app/service.go", Line: 12, Function: "app.run"},
+				{File: "/app/main.go", Line: 42, Function: "main.main"},
+			},
+		})
+
+		assert.Equal(
+			t,
+			`{"time":"2020-08-25T19:06:36.123456789Z","level":"error","message":"test message","stack":[`+
+				`{"file":"/app/service.go","line":12,"function":"app.run"},{"file":"/app/main.go","line":42,"function":"main.main"}]}`,
+			formatted.FormattedMessage,
+		)
+	})
+
+	t.Run("with fields", func(t *testing.T) {
+		formatter := NewJSONFormatter()
+		formatted := formatter.Format(Log{
+			Level:     LevelInfo,
+			Message:   "test message",
+			Data:      Data{"key": "val", "answer": "41"},
+			Fields:    Fields{Int("answer", 42), Bool("ok", true), Object("user", String("name", "john"), Float("score", 1.5))},
+			CreatedAt: tm,
+		})
+
+		assert.Equal(
+			t,
+			`{"time":"2020-08-25T19:06:36.123456789Z","level":"info","message":"test message",`+
+				`"data":{"answer":42,"key":"val","ok":true,"user":{"name":"john","score":1.5}}}`,
+			formatted.FormattedMessage,
+		)
+	})
+
+	t.Run("with flattened fields", func(t *testing.T) {
+		formatter := NewJSONFormatter().WithDataKey("")
+		formatted := formatter.Format(Log{
+			Level:     LevelInfo,
+			Message:   "test message",
+			Data:      Data{},
+			Fields:    Fields{Int("answer", 42), Any("message", []string{"a"})},
+			CreatedAt: tm,
+		})
+
+		assert.Equal(
+			t,
+			`{"time":"2020-08-25T19:06:36.123456789Z","level":"info","message":"test message","answer":42,"data.message":["a"]}`,
+			formatted.FormattedMessage,
+		)
+	})
+
+	t.Run("with control characters", func(t *testing.T) {
+		formatter := NewJSONFormatter()
+		formatted := formatter.Format(Log{
+			Level:     LevelWarning,
+			Message:   "line\nnext \"quoted\" \t <tag> \x01",
+			Data:      Data{"key\n": "val\r"},
+			CreatedAt: tm,
+		})
+
+		decoded := make(map[string]interface{})
+
+		if assert.NoError(t, json.Unmarshal([]byte(formatted.FormattedMessage), &decoded)) {
+			assert.Equal(t, "line\nnext \"quoted\" \t <tag> \x01", decoded["message"])
+			assert.Equal(t, map[string]interface{}{"key\n": "val\r"}, decoded["data"])
+		}
+
+		assert.NotContains(t, formatted.FormattedMessage, "\n")
+	})
+}
@@ -2,6 +2,11 @@ package logger
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 type (
@@ -16,6 +21,8 @@ const (
 	LevelWarning  = Level(2000)
 	LevelError    = Level(3000)
 	LevelCritical = Level(9001)
+	// LevelMax is the highest possible level.
+	LevelMax = Level(math.MaxUint32)
 
 	LevelNameDebug    = LevelName("debug")
 	LevelNameInfo     = LevelName("info")
@@ -27,7 +34,10 @@ const (
 var (
 	ErrLevelMappingNotFound     = errors.New("level cannot be mapped to level name")
 	ErrLevelNameMappingNotFound = errors.New("level name cannot be mapped to level")
+	ErrLevelAlreadyRegistered   = errors.New("level is already registered with different name")
+	ErrLevelNameAlreadyUsed     = errors.New("level name is already used by different level")
 
+	levelsLock    sync.RWMutex
 	levelsMapping = map[LevelName]Level{
 		LevelNameDebug:    LevelDebug,
 		LevelNameInfo:     LevelInfo,
@@ -48,7 +58,36 @@ func (l Level) EqualOrGreaterThan(level Level) bool {
 	return l >= level
 }
 
+// RegisterLevel adds custom level (e.g. "notice" or "audit") so it can be
+// mapped to its name (and back) by Level.Name and LevelName.Level. Registering
+// the same level and name again does nothing, but level and name already
+// registered with different counterpart are rejected.
+func RegisterLevel(level Level, name LevelName) error {
+	levelsLock.Lock()
+	defer levelsLock.Unlock()
+
+	if current, ok := levelsReverseMapping[level]; ok {
+		if current == name {
+			return nil
+		}
+
+		return fmt.Errorf("%w: %d is registered as \"%s\"", ErrLevelAlreadyRegistered, level, current)
+	}
+
+	if current, ok := levelsMapping[name]; ok {
+		return fmt.Errorf("%w: \"%s\" is registered for %d", ErrLevelNameAlreadyUsed, name, current)
+	}
+
+	levelsMapping[name] = level
+	levelsReverseMapping[level] = name
+
+	return nil
+}
+
 func (l Level) Name() (LevelName, error) {
+	levelsLock.RLock()
+	defer levelsLock.RUnlock()
+
 	val, ok := levelsReverseMapping[l]
 
 	if !ok {
@@ -59,6 +98,9 @@ func (l Level) Name() (LevelName, error) {
 }
 
 func (ln LevelName) Level() (Level, error) {
+	levelsLock.RLock()
+	defer levelsLock.RUnlock()
+
 	val, ok := levelsMapping[ln]
 
 	if !ok {
@@ -68,6 +110,50 @@ func (ln LevelName) Level() (Level, error) {
 	return val, nil
 }
 
+// lowerLevel returns the closest registered level lower than provided one.
+func lowerLevel(level Level) (Level, bool) {
+	levelsLock.RLock()
+	defer levelsLock.RUnlock()
+
+	res, found := Level(0), false
+
+	for registered := range levelsReverseMapping {
+		if registered < level && (!found || registered > res) {
+			res, found = registered, true
+		}
+	}
+
+	return res, found
+}
+
+// parseLevel maps level name (case-insensitive) or numeric value to Level.
+func parseLevel(value string) (Level, error) {
+	level, err := LevelName(strings.ToLower(strings.TrimSpace(value))).Level()
+
+	if err == nil {
+		return level, nil
+	}
+
+	num, numErr := strconv.ParseUint(strings.TrimSpace(value), 10, 32)
+
+	if numErr != nil {
+		return 0, fmt.Errorf("%w: \"%s\"", ErrLevelMappingNotFound, value)
+	}
+
+	return Level(num), nil
+}
+
+// levelString returns name of the level or its numeric value if level has no name.
+func levelString(level Level) string {
+	name, err := level.Name()
+
+	if err != nil {
+		return strconv.Itoa(level.Int())
+	}
+
+	return name.String()
+}
+
 func (l Level) Int() int {
 	return int(l)
 }
@@ -0,0 +1,204 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// LevelAdminHandler is http.Handler which allows to check and change levels
+// of MainLogger at runtime. GET returns current levels as JSON:
+//
+//	{"level":"info","named":{"db":"debug"}}
+//
+// PUT and POST change level (or level for logger name) with JSON body:
+//
+//	{"level":"debug","name":"db","ttl":"15m"}
+//
+// Empty "name" changes default level, empty "level" with "name" removes level
+// for the name. Optional "ttl" (or default one set with WithRevertAfter)
+// reverts the change after provided time.
+type LevelAdminHandler struct {
+	logger      *MainLogger
+	revertAfter time.Duration
+	lock        sync.Mutex
+	reverts     map[string]*levelRevert
+}
+
+// maxLevelAdminBodySize limits size of request body read by LevelAdminHandler.
+const maxLevelAdminBodySize = 1 << 20
+
+type levelRevert struct {
+	timer    *time.Timer
+	level    Level
+	hadLevel bool
+}
+
+type levelAdminRequest struct {
+	Name  string `json:"name"`
+	Level string `json:"level"`
+	TTL   string `json:"ttl"`
+}
+
+type levelAdminResponse struct {
+	Level string            `json:"level"`
+	Named map[string]string `json:"named"`
+}
+
+type levelAdminError struct {
+	Error string `json:"error"`
+}
+
+func NewLevelAdminHandler(logger *MainLogger) *LevelAdminHandler {
+	return &LevelAdminHandler{logger: logger, reverts: make(map[string]*levelRevert)}
+}
+
+// WithRevertAfter sets default time after which changed level
+// is reverted (zero - changes are permanent, default).
+func (h *LevelAdminHandler) WithRevertAfter(ttl time.Duration) *LevelAdminHandler {
+	h.revertAfter = ttl
+
+	return h
+}
+
+func (h *LevelAdminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.writeResponse(w, http.StatusOK, h.currentLevels())
+	case http.MethodPut, http.MethodPost:
+		req := levelAdminRequest{}
+		err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxLevelAdminBodySize)).Decode(&req)
+		maxBytesErr := &http.MaxBytesError{}
+
+		if errors.As(err, &maxBytesErr) {
+			h.writeResponse(w, http.StatusRequestEntityTooLarge, levelAdminError{Error: "request body too large"})
+
+			return
+		}
+
+		if err != nil {
+			h.writeResponse(w, http.StatusBadRequest, levelAdminError{Error: fmt.Sprintf("invalid request body: %s", err)})
+
+			return
+		}
+
+		err = h.change(req)
+
+		if err != nil {
+			h.writeResponse(w, http.StatusBadRequest, levelAdminError{Error: err.Error()})
+
+			return
+		}
+
+		h.writeResponse(w, http.StatusOK, h.currentLevels())
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST")
+		h.writeResponse(w, http.StatusMethodNotAllowed, levelAdminError{Error: "method not allowed"})
+	}
+}
+
+func (h *LevelAdminHandler) change(req levelAdminRequest) error {
+	ttl := h.revertAfter
+
+	if req.TTL != "" {
+		parsed, err := time.ParseDuration(req.TTL)
+
+		if err != nil || parsed < 0 {
+			return fmt.Errorf("invalid ttl: \"%s\"", req.TTL)
+		}
+
+		ttl = parsed
+	}
+
+	if req.Level == "" && req.Name == "" {
+		return fmt.Errorf("level is required")
+	}
+
+	var level Level
+
+	if req.Level != "" {
+		parsed, err := parseLevel(req.Level)
+
+		if err != nil {
+			return err
+		}
+
+		level = parsed
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	prev, hadPrev := h.getLevel(req.Name)
+
+	if revert, ok := h.reverts[req.Name]; ok {
+		revert.timer.Stop()
+		delete(h.reverts, req.Name)
+		prev, hadPrev = revert.level, revert.hadLevel
+	}
+
+	h.setLevel(req.Name, level, req.Level != "")
+
+	if ttl > 0 {
+		revert := &levelRevert{level: prev, hadLevel: hadPrev}
+		revert.timer = time.AfterFunc(ttl, func() {
+			h.revert(req.Name, revert)
+		})
+		h.reverts[req.Name] = revert
+	}
+
+	return nil
+}
+
+func (h *LevelAdminHandler) revert(name string, revert *levelRevert) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.reverts[name] != revert {
+		return
+	}
+
+	delete(h.reverts, name)
+	h.setLevel(name, revert.level, revert.hadLevel)
+}
+
+func (h *LevelAdminHandler) getLevel(name string) (Level, bool) {
+	if name == "" {
+		return h.logger.config.getLevel(), true
+	}
+
+	level, ok := h.logger.NamedLevels()[name]
+
+	return level, ok
+}
+
+func (h *LevelAdminHandler) setLevel(name string, level Level, hasLevel bool) {
+	switch {
+	case name == "":
+		h.logger.config.setLevel(level)
+	case hasLevel:
+		h.logger.SetNamedLevel(name, level)
+	default:
+		h.logger.ResetNamedLevel(name)
+	}
+}
+
+func (h *LevelAdminHandler) currentLevels() levelAdminResponse {
+	res := levelAdminResponse{Level: levelString(h.logger.config.getLevel()), Named: make(map[string]string)}
+
+	for name, level := range h.logger.NamedLevels() {
+		res.Named[name] = levelString(level)
+	}
+
+	return res
+}
+
+func (h *LevelAdminHandler) writeResponse(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLevelAdminHandler_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	logger := New(NewInMemoryHandler(0))
+	logger.SetLevel(LevelInfo)
+	logger.SetNamedLevel("db", LevelDebug)
+
+	handler := NewLevelAdminHandler(logger)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/log-level", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"level":"info","named":{"db":"debug"}}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/log-level", strings.NewReader(`{"level":"WARNING"}`)))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"level":"warning","named":{"db":"debug"}}`, rec.Body.String())
+	assert.Equal(t, LevelWarning, logger.Level())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/log-level", strings.NewReader(`{"name":"http","level":"2500"}`)))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"level":"warning","named":{"db":"debug","http":"2500"}}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/log-level", strings.NewReader(`{"name":"db"}`)))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"level":"warning","named":{"http":"2500"}}`, rec.Body.String())
+}
+
+func TestLevelAdminHandler_ServeHTTP_Failure(t *testing.T) {
+	t.Parallel()
+
+	handler := NewLevelAdminHandler(New(NewInMemoryHandler(0)))
+
+	expected := map[string]string{
+		`{"level":"verbose-x"}`:       `{"error":"level cannot be mapped to level name: \"verbose-x\""}`,
+		`{"level":"debug","ttl":"x"}`: `{"error":"invalid ttl: \"x\""}`,
+		`{}`:                          `{"error":"level is required"}`,
+		`[`:                           `{"error":"invalid request body: unexpected EOF"}`,
+	}
+
+	for body, response := range expected {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/log-level", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+		assert.JSONEq(t, response, rec.Body.String(), body)
+	}
+
+	rec := httptest.NewRecorder()
+	body := `{"level":"debug","name":"` + strings.Repeat("x", maxLevelAdminBodySize) + `"}`
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/log-level", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.JSONEq(t, `{"error":"request body too large"}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/log-level", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET, PUT, POST", rec.Header().Get("Allow"))
+}
+
+func TestLevelAdminHandler_WithRevertAfter(t *testing.T) {
+	t.Parallel()
+
+	logger := New(NewInMemoryHandler(0))
+	logger.SetLevel(LevelInfo)
+
+	handler := NewLevelAdminHandler(logger).WithRevertAfter(time.Hour)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"level":"error"}`)))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"level":"debug","ttl":"20ms"}`)))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"name":"db","level":"debug","ttl":"20ms"}`)))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, LevelDebug, logger.Level())
+	assert.Equal(t, LevelDebug, logger.Named("db").Level())
+
+	assert.Eventually(t, func() bool {
+		return logger.Level() == LevelInfo && len(logger.NamedLevels()) == 0
+	}, time.Second, 5*time.Millisecond)
+}
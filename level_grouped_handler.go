@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 )
@@ -10,78 +11,249 @@ type LevelGroup struct {
 	Handler Handler
 }
 
+// LevelRangeGroup matches logs with level between MinLevel and MaxLevel
+// (both inclusive). LevelMax can be used as MaxLevel for "MinLevel or above".
+type LevelRangeGroup struct {
+	MinLevel Level
+	MaxLevel Level
+	Handler  Handler
+}
+
+// PredicateGroup matches logs for which Match returns true
+// (e.g. based on message, Data keys or level).
+type PredicateGroup struct {
+	Match   func(log Log) bool
+	Handler Handler
+}
+
+type levelRoute struct {
+	match   func(log Log) bool
+	handler Handler
+}
+
+// LevelGroupedHandler passes logs to handlers from all matching groups
+// (in order in which groups were added) or to fallback handler
+// when none of groups matches. Failure of one handler does not stop
+// the others, errors from all failing handlers are returned joined together.
 type LevelGroupedHandler struct {
-	handlers        map[Level][]Handler
+	routes          []levelRoute
 	fallbackHandler Handler
+	firstMatchOnly  bool
 }
 
 func NewLevelGroupedHandler(fallbackHandler Handler, groups ...LevelGroup) *LevelGroupedHandler {
-	h := &LevelGroupedHandler{fallbackHandler: fallbackHandler, handlers: make(map[Level][]Handler)}
+	h := &LevelGroupedHandler{fallbackHandler: fallbackHandler}
 	h.createHandlersList(groups)
 
 	return h
 }
 
+// WithRangeGroups adds groups matching logs by level range.
+func (h *LevelGroupedHandler) WithRangeGroups(groups ...LevelRangeGroup) *LevelGroupedHandler {
+	for _, group := range groups {
+		minLevel, maxLevel := group.MinLevel, group.MaxLevel
+
+		h.routes = append(h.routes, levelRoute{
+			match: func(log Log) bool {
+				return log.Level >= minLevel && log.Level <= maxLevel
+			},
+			handler: group.Handler,
+		})
+	}
+
+	return h
+}
+
+// WithPredicateGroups adds groups matching logs by custom predicate.
+func (h *LevelGroupedHandler) WithPredicateGroups(groups ...PredicateGroup) *LevelGroupedHandler {
+	for _, group := range groups {
+		h.routes = append(h.routes, levelRoute{match: group.Match, handler: group.Handler})
+	}
+
+	return h
+}
+
+// WithFirstMatchOnly makes handler pass log only to the first
+// matching group instead of all matching groups.
+func (h *LevelGroupedHandler) WithFirstMatchOnly() *LevelGroupedHandler {
+	h.firstMatchOnly = true
+
+	return h
+}
+
 func (h *LevelGroupedHandler) Handle(log Log) error {
-	handlers, ok := h.handlers[log.Level]
+	routes := h.matchRoutes(log)
 
-	if !ok || len(handlers) == 0 {
+	if len(routes) == 0 {
 		err := h.fallbackHandler.Handle(log)
 
 		if err != nil {
-			return fmt.Errorf("LevelGroupedHandler - fallback handler returned an error: %w", err)
+			return fmt.Errorf("LevelGroupedHandler - fallback handler returned an error: %w", h.failure(-1, err, true))
 		}
 
 		return nil
 	}
 
-	for _, handler := range handlers {
-		err := handler.Handle(log)
+	errs := make([]error, len(routes))
+	delivered := false
 
-		if err != nil {
-			return fmt.Errorf("LevelGroupedHandler - one of handlers returned an error: %w", err)
+	for pos, idx := range routes {
+		errs[pos] = h.routes[idx].handler.Handle(log)
+
+		if errs[pos] == nil {
+			delivered = true
+		}
+	}
+
+	for pos, idx := range routes {
+		if errs[pos] != nil {
+			errs[pos] = fmt.Errorf("LevelGroupedHandler - one of handlers returned an error: %w", h.failure(idx, errs[pos], !delivered))
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
+// HandleBatch passes to each matching handler a single batch with logs
+// in original order. Handlers are called in order of the lowest level
+// they received.
 func (h *LevelGroupedHandler) HandleBatch(logs []Log) error {
-	var levels Levels
-	levelLogs := make(map[Level][]Log)
+	const fallback = -1
 
-	for _, l := range logs {
-		levelLogs[l.Level] = append(levelLogs[l.Level], l)
-		levels = append(levels, l.Level)
+	sorted := make([]int, len(logs))
+
+	for idx := range sorted {
+		sorted[idx] = idx
 	}
 
-	sort.Sort(levels)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return logs[sorted[i]].Level < logs[sorted[j]].Level
+	})
+
+	matched := make([][]int, len(logs))
+
+	for idx, log := range logs {
+		matched[idx] = h.matchRoutes(log)
 
-	for _, lvl := range levels {
-		if hd, hOk := h.handlers[lvl]; hOk {
-			for _, hand := range hd {
-				hErr := hand.HandleBatch(levelLogs[lvl])
+		if len(matched[idx]) == 0 {
+			matched[idx] = []int{fallback}
+		}
+	}
 
-				if hErr != nil {
-					return fmt.Errorf("LevelGroupedHandler - one of handlers returned an error: %w", hErr)
-				}
+	var order []int
+	routeLogs := make(map[int][]Log)
+
+	for _, logIdx := range sorted {
+		for _, route := range matched[logIdx] {
+			if _, ok := routeLogs[route]; !ok {
+				order = append(order, route)
+				routeLogs[route] = nil
 			}
+		}
+	}
+
+	for idx, log := range logs {
+		for _, route := range matched[idx] {
+			routeLogs[route] = append(routeLogs[route], log)
+		}
+	}
+
+	routeErrs := make(map[int]error)
+
+	for _, route := range order {
+		if route == fallback {
+			routeErrs[route] = h.fallbackHandler.HandleBatch(routeLogs[route])
 		} else {
-			hErr := h.fallbackHandler.HandleBatch(levelLogs[lvl])
+			routeErrs[route] = h.routes[route].handler.HandleBatch(routeLogs[route])
+		}
+	}
+
+	// log is dropped when none of handlers it was passed to handled it
+	droppedRoutes := make(map[int]bool)
+
+	for _, routes := range matched {
+		delivered := false
 
-			if hErr != nil {
-				return fmt.Errorf("LevelGroupedHandler - fallback handler returned an error: %w", hErr)
+		for _, route := range routes {
+			if routeErrs[route] == nil {
+				delivered = true
 			}
 		}
+
+		if !delivered {
+			for _, route := range routes {
+				droppedRoutes[route] = true
+			}
+		}
+	}
+
+	var errs []error
+
+	for _, route := range order {
+		hErr := routeErrs[route]
+
+		if hErr == nil {
+			continue
+		}
+
+		if route == fallback {
+			errs = append(errs, fmt.Errorf("LevelGroupedHandler - fallback handler returned an error: %w", h.failure(-1, hErr, true)))
+		} else {
+			errs = append(errs, fmt.Errorf("LevelGroupedHandler - one of handlers returned an error: %w", h.failure(route, hErr, droppedRoutes[route])))
+		}
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+func (h *LevelGroupedHandler) children() []Handler {
+	res := make([]Handler, 0, len(h.routes)+1)
+
+	for _, route := range h.routes {
+		res = append(res, route.handler)
+	}
+
+	return append(res, h.fallbackHandler)
+}
+
+// failure describes error of handler from group with provided index (-1 for fallback
+// handler). Log is not dropped when it was handled by other handler from group.
+func (h *LevelGroupedHandler) failure(index int, err error, dropped bool) *HandlerFailure {
+	return &HandlerFailure{Handler: "LevelGroupedHandler", Index: index, Attempts: 1, Dropped: dropped, Err: err}
+}
+
+// matchRoutes returns indexes of routes matching log.
+func (h *LevelGroupedHandler) matchRoutes(log Log) []int {
+	var res []int
+
+	for idx, route := range h.routes {
+		if !route.match(log) {
+			continue
+		}
+
+		res = append(res, idx)
+
+		if h.firstMatchOnly {
+			break
+		}
+	}
+
+	return res
 }
 
 func (h *LevelGroupedHandler) createHandlersList(groups []LevelGroup) {
 	for _, group := range groups {
+		levels := make(map[Level]bool)
+
 		for _, level := range group.Levels {
-			h.handlers[level] = append(h.handlers[level], group.Handler)
+			levels[level] = true
 		}
+
+		h.routes = append(h.routes, levelRoute{
+			match: func(log Log) bool {
+				return levels[log.Level]
+			},
+			handler: group.Handler,
+		})
 	}
 }
@@ -165,6 +165,10 @@ func TestLevelGroupedHandler_Handle_FallbackHandlerFailure(t *testing.T) {
 	if assert.Error(t, warningErr) {
 		assert.EqualError(t, warningErr, "LevelGroupedHandler - fallback handler returned an error: test")
 
+		failure := &HandlerFailure{}
+		assert.True(t, errors.As(warningErr, &failure))
+		assert.Equal(t, -1, failure.Index)
+
 		mFallbackHandler.AssertExpectations(t)
 		mHandler1.AssertExpectations(t)
 		mHandler2.AssertExpectations(t)
@@ -223,6 +227,10 @@ func TestLevelGroupedHandler_Handle_GroupedHandlerFailure(t *testing.T) {
 	if assert.Error(t, infoErr) {
 		assert.EqualError(t, infoErr, "LevelGroupedHandler - one of handlers returned an error: test")
 
+		failure := &HandlerFailure{}
+		assert.True(t, errors.As(infoErr, &failure))
+		assert.Equal(t, HandlerFailure{Handler: "LevelGroupedHandler", Index: 0, Attempts: 1, Dropped: true, Err: errors.New("test")}, *failure)
+
 		mFallbackHandler.AssertExpectations(t)
 		mHandler1.AssertExpectations(t)
 		mHandler2.AssertExpectations(t)
@@ -260,6 +268,7 @@ func TestLevelGroupedHandler_HandleBatch_FallbackHandlerFailure(t *testing.T) {
 	mHandler1.On("HandleBatch", []Log{logs[0]}).Return(nil)
 
 	mHandler2 := &mockHandler{}
+	mHandler2.On("HandleBatch", []Log{logs[2]}).Return(nil)
 
 	handler := NewLevelGroupedHandler(mFallbackHandler, LevelGroup{
 		Levels:  []Level{LevelInfo},
@@ -276,7 +285,7 @@ func TestLevelGroupedHandler_HandleBatch_FallbackHandlerFailure(t *testing.T) {
 
 		mFallbackHandler.AssertExpectations(t)
 		mHandler1.AssertExpectations(t)
-		mHandler2.AssertNotCalled(t, "HandleBatch", []Log{logs[2]})
+		mHandler2.AssertExpectations(t)
 	}
 }
 
@@ -331,3 +340,179 @@ func TestLevelGroupedHandler_HandleBatch_GroupedHandlerFailure(t *testing.T) {
 		mHandler2.AssertExpectations(t)
 	}
 }
+
+func TestLevelGroupedHandler_Handle_AllMatchesFailure(t *testing.T) {
+	t.Parallel()
+
+	log := Log{Level: LevelError, Message: "test error", Data: make(Data), CreatedAt: time.Now()}
+
+	mHandler1 := &mockHandler{}
+	mHandler1.On("Handle", log).Return(errors.New("first"))
+
+	mHandler2 := &mockHandler{}
+	mHandler2.On("Handle", log).Return(nil)
+
+	mHandler3 := &mockHandler{}
+	mHandler3.On("Handle", log).Return(errors.New("third"))
+
+	handler := NewLevelGroupedHandler(NewInMemoryHandler(0)).WithRangeGroups(
+		LevelRangeGroup{MinLevel: LevelInfo, MaxLevel: LevelMax, Handler: mHandler1},
+		LevelRangeGroup{MinLevel: LevelWarning, MaxLevel: LevelMax, Handler: mHandler2},
+		LevelRangeGroup{MinLevel: LevelError, MaxLevel: LevelMax, Handler: mHandler3},
+	)
+
+	err := handler.Handle(log)
+
+	if assert.Error(t, err) {
+		assert.EqualError(t, err, "LevelGroupedHandler - one of handlers returned an error: first\n"+
+			"LevelGroupedHandler - one of handlers returned an error: third")
+
+		failure := &HandlerFailure{}
+		assert.True(t, errors.As(err, &failure))
+		assert.Equal(t, 0, failure.Index)
+		assert.False(t, failure.Dropped)
+
+		mHandler1.AssertExpectations(t)
+		mHandler2.AssertExpectations(t)
+		mHandler3.AssertExpectations(t)
+	}
+}
+
+func TestLevelGroupedHandler_HandleBatch_Dropped(t *testing.T) {
+	t.Parallel()
+
+	logs := []Log{
+		{Level: LevelInfo, Message: "test info", Data: make(Data), CreatedAt: time.Now()},
+		{Level: LevelError, Message: "test error", Data: make(Data), CreatedAt: time.Now()},
+		{Level: LevelCritical, Message: "test critical", Data: make(Data), CreatedAt: time.Now()},
+	}
+
+	mHandler1 := &mockHandler{}
+	mHandler1.On("HandleBatch", logs[:2]).Return(nil)
+
+	mHandler2 := &mockHandler{}
+	mHandler2.On("HandleBatch", logs[1:2]).Return(errors.New("second"))
+
+	mHandler3 := &mockHandler{}
+	mHandler3.On("HandleBatch", logs[2:]).Return(errors.New("third"))
+
+	handler := NewLevelGroupedHandler(NewInMemoryHandler(0)).WithRangeGroups(
+		LevelRangeGroup{MinLevel: LevelInfo, MaxLevel: LevelError, Handler: mHandler1},
+		LevelRangeGroup{MinLevel: LevelError, MaxLevel: LevelError, Handler: mHandler2},
+		LevelRangeGroup{MinLevel: LevelCritical, MaxLevel: LevelMax, Handler: mHandler3},
+	)
+
+	err := handler.HandleBatch(logs)
+
+	if assert.Error(t, err) {
+		errs := err.(interface{ Unwrap() []error }).Unwrap()
+
+		if assert.Len(t, errs, 2) {
+			failure := &HandlerFailure{}
+			assert.True(t, errors.As(errs[0], &failure))
+			assert.Equal(t, 1, failure.Index)
+			assert.False(t, failure.Dropped)
+
+			assert.True(t, errors.As(errs[1], &failure))
+			assert.Equal(t, 2, failure.Index)
+			assert.True(t, failure.Dropped)
+		}
+	}
+}
+
+func TestLevelGroupedHandler_WithRangeGroups(t *testing.T) {
+	t.Parallel()
+
+	logInfo := Log{Level: LevelInfo, Message: "test info", Data: make(Data), CreatedAt: time.Now()}
+	logCustom := Log{Level: Level(5000), Message: "test custom", Data: make(Data), CreatedAt: time.Now()}
+	logCritical := Log{Level: LevelCritical, Message: "test critical", Data: make(Data), CreatedAt: time.Now()}
+
+	mFallbackHandler := &mockHandler{}
+	mFallbackHandler.On("Handle", logInfo).Return(nil).Once()
+
+	mHandler1 := &mockHandler{}
+	mHandler1.On("Handle", logCustom).Return(nil).Once()
+	mHandler1.On("Handle", logCritical).Return(nil).Once()
+
+	mHandler2 := &mockHandler{}
+	mHandler2.On("Handle", logCritical).Return(nil).Once()
+
+	handler := NewLevelGroupedHandler(mFallbackHandler, LevelGroup{
+		Levels:  []Level{LevelCritical},
+		Handler: mHandler2,
+	}).WithRangeGroups(LevelRangeGroup{MinLevel: LevelWarning, MaxLevel: LevelMax, Handler: mHandler1})
+
+	assert.NoError(t, handler.Handle(logInfo))
+	assert.NoError(t, handler.Handle(logCustom))
+	assert.NoError(t, handler.Handle(logCritical))
+
+	mFallbackHandler.AssertExpectations(t)
+	mHandler1.AssertExpectations(t)
+	mHandler2.AssertExpectations(t)
+}
+
+func TestLevelGroupedHandler_WithPredicateGroups_FirstMatchOnly(t *testing.T) {
+	t.Parallel()
+
+	logAudit := Log{Level: LevelError, Message: "test audit", Data: Data{"audit": "1"}, CreatedAt: time.Now()}
+	logError := Log{Level: LevelError, Message: "test error", Data: make(Data), CreatedAt: time.Now()}
+
+	mFallbackHandler := &mockHandler{}
+
+	mHandler1 := &mockHandler{}
+	mHandler1.On("Handle", logAudit).Return(nil).Once()
+
+	mHandler2 := &mockHandler{}
+	mHandler2.On("Handle", logError).Return(nil).Once()
+
+	handler := NewLevelGroupedHandler(mFallbackHandler).WithPredicateGroups(PredicateGroup{
+		Match: func(log Log) bool {
+			_, ok := log.Data["audit"]
+
+			return ok
+		},
+		Handler: mHandler1,
+	}).WithRangeGroups(LevelRangeGroup{
+		MinLevel: LevelError,
+		MaxLevel: LevelError,
+		Handler:  mHandler2,
+	}).WithFirstMatchOnly()
+
+	assert.NoError(t, handler.Handle(logAudit))
+	assert.NoError(t, handler.Handle(logError))
+
+	mFallbackHandler.AssertExpectations(t)
+	mHandler1.AssertExpectations(t)
+	mHandler2.AssertExpectations(t)
+}
+
+func TestLevelGroupedHandler_HandleBatch_WithRangeGroups(t *testing.T) {
+	t.Parallel()
+
+	logs := []Log{
+		{Level: LevelError, Message: "test error", Data: make(Data), CreatedAt: time.Now()},
+		{Level: LevelDebug, Message: "test debug", Data: make(Data), CreatedAt: time.Now()},
+		{Level: LevelWarning, Message: "test warning", Data: make(Data), CreatedAt: time.Now()},
+		{Level: LevelInfo, Message: "test info", Data: make(Data), CreatedAt: time.Now()},
+	}
+
+	mFallbackHandler := &mockHandler{}
+	mFallbackHandler.On("HandleBatch", []Log{logs[1]}).Return(nil).Once()
+
+	mHandler1 := &mockHandler{}
+	mHandler1.On("HandleBatch", []Log{logs[0], logs[2], logs[3]}).Return(nil).Once()
+
+	mHandler2 := &mockHandler{}
+	mHandler2.On("HandleBatch", []Log{logs[0], logs[2]}).Return(nil).Once()
+
+	handler := NewLevelGroupedHandler(mFallbackHandler).WithRangeGroups(
+		LevelRangeGroup{MinLevel: LevelInfo, MaxLevel: LevelMax, Handler: mHandler1},
+		LevelRangeGroup{MinLevel: LevelWarning, MaxLevel: LevelMax, Handler: mHandler2},
+	)
+
+	assert.NoError(t, handler.HandleBatch(logs))
+
+	mFallbackHandler.AssertExpectations(t)
+	mHandler1.AssertExpectations(t)
+	mHandler2.AssertExpectations(t)
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"sort"
 	"testing"
@@ -92,3 +93,35 @@ func TestLevels_Sorting(t *testing.T) {
 
 	assert.Equal(t, Levels{LevelDebug, LevelInfo, LevelWarning, LevelError, LevelCritical}, lvl)
 }
+
+func TestRegisterLevel(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, RegisterLevel(Level(1500), "notice"))
+	assert.NoError(t, RegisterLevel(Level(1500), "notice"))
+
+	ln, lnErr := Level(1500).Name()
+	assert.Equal(t, LevelName("notice"), ln)
+	assert.NoError(t, lnErr)
+
+	l, lErr := LevelName("notice").Level()
+	assert.Equal(t, Level(1500), l)
+	assert.NoError(t, lErr)
+
+	levelErr := RegisterLevel(Level(1500), "notice2")
+
+	if assert.Error(t, levelErr) {
+		assert.True(t, errors.Is(levelErr, ErrLevelAlreadyRegistered))
+		assert.EqualError(t, levelErr, "level is already registered with different name: 1500 is registered as \"notice\"")
+	}
+
+	nameErr := RegisterLevel(Level(1501), LevelNameWarning)
+
+	if assert.Error(t, nameErr) {
+		assert.True(t, errors.Is(nameErr, ErrLevelNameAlreadyUsed))
+		assert.EqualError(t, nameErr, "level name is already used by different level: \"warning\" is registered for 2000")
+	}
+
+	_, unknownErr := Level(1501).Name()
+	assert.Error(t, unknownErr)
+}
@@ -1,12 +1,20 @@
 package logger
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type Log struct {
 	Level     Level
+	Name      string
 	Message   string
 	Data      Data
+	Fields    Fields
 	CreatedAt time.Time
+	Context   context.Context
+	Caller    *Caller
+	Stack     StackTrace
 }
 
 type FormattedLog struct {
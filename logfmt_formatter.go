@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// LogfmtFormatter creates message in logfmt format
+// (example: `time=2020-08-25T19:06:36+02:00 level=info msg="server started" port=17333`).
+type LogfmtFormatter struct {
+	dateFormat string
+}
+
+func NewLogfmtFormatter(dateFormat string) *LogfmtFormatter {
+	return &LogfmtFormatter{dateFormat: dateFormat}
+}
+
+func (f *LogfmtFormatter) Format(log Log) FormattedLog {
+	return FormattedLog{
+		Log:              log,
+		FormattedMessage: f.createFormattedMessage(log),
+	}
+}
+
+func (f *LogfmtFormatter) createFormattedMessage(log Log) string {
+	ln, lnErr := log.Level.Name()
+
+	if errors.Is(lnErr, ErrLevelNameMappingNotFound) {
+		ln = "unknown"
+	}
+
+	res := &strings.Builder{}
+	f.writePair(res, "time", log.CreatedAt.Format(f.dateFormat))
+	f.writePair(res, "level", ln.String())
+
+	if log.Name != "" {
+		f.writePair(res, "logger", log.Name)
+	}
+
+	data := log.Data.withFields(log.Fields)
+	f.writePair(res, "msg", applyDataOnMessage(log.Message, data))
+
+	if log.Caller != nil {
+		f.writePair(res, "caller", log.Caller.String())
+		f.writePair(res, "func", log.Caller.Function)
+	}
+
+	for _, key := range sortedDataKeys(data) {
+		f.writePair(res, key, data[key])
+	}
+
+	return res.String()
+}
+
+func (f *LogfmtFormatter) writePair(res *strings.Builder, key string, value string) {
+	if res.Len() > 0 {
+		res.WriteString(" ")
+	}
+
+	res.WriteString(logfmtKey(key))
+	res.WriteString("=")
+	res.WriteString(logfmtValue(value))
+}
+
+// logfmtKey replaces all characters which are not allowed
+// in logfmt keys with underscore.
+func logfmtKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || !unicode.IsPrint(r) {
+			return '_'
+		}
+
+		return r
+	}, key)
+}
+
+// logfmtValue quotes value if it is empty or contains spaces,
+// "=", quotes or non-printable characters.
+func logfmtValue(value string) string {
+	if value == "" {
+		return `""`
+	}
+
+	needsQuoting := strings.IndexFunc(value, func(r rune) bool {
+		return r <= ' ' || r == '=' || r == '"' || r == '\\' || !unicode.IsPrint(r)
+	}) >= 0
+
+	if needsQuoting {
+		return strconv.Quote(value)
+	}
+
+	return value
+}
@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLogfmtFormatter_Format(t *testing.T) {
+	t.Parallel()
+
+	tm := time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC)
+
+	t.Run("without additional data", func(t *testing.T) {
+		formatter := NewLogfmtFormatter(time.RFC3339)
+		formatted := formatter.Format(Log{
+			Level:     LevelInfo,
+			Message:   "test",
+			Data:      Data{},
+			CreatedAt: tm,
+		})
+
+		assert.IsType(t, FormattedLog{}, formatted)
+		assert.Equal(t, "time=2020-08-25T19:06:36Z level=info msg=test", formatted.FormattedMessage)
+	})
+
+	t.Run("with additional data", func(t *testing.T) {
+		formatter := NewLogfmtFormatter(time.RFC3339)
+		formatted := formatter.Format(Log{
+			Level:     LevelWarning,
+			Message:   "test message {answer}",
+			Data:      Data{"key": "val", "answer": "42", "addr": "host:80", "query": "a=b", "quote": `say "hi"`, "empty": ""},
+			CreatedAt: tm,
+		})
+
+		assert.Equal(
+			t,
+			`time=2020-08-25T19:06:36Z level=warning msg="test message 42" addr=host:80 answer=42 empty="" key=val query="a=b" quote="say \"hi\""`,
+			formatted.FormattedMessage,
+		)
+	})
+
+	t.Run("with logger name", func(t *testing.T) {
+		formatter := NewLogfmtFormatter(time.RFC3339)
+		formatted := formatter.Format(Log{
+			Level:     LevelInfo,
+			Name:      "db.pool",
+			Message:   "test",
+			Data:      Data{},
+			CreatedAt: tm,
+		})
+
+		assert.Equal(t, "time=2020-08-25T19:06:36Z level=info logger=db.pool msg=test", formatted.FormattedMessage)
+	})
+
+	t.Run("with unknown level and invalid key", func(t *testing.T) {
+		formatter := NewLogfmtFormatter(time.RFC3339)
+		formatted := formatter.Format(Log{
+			Level:     Level(5000),
+			Message:   "line\nnext",
+			Data:      Data{"my key=": "val\\ue"},
+			CreatedAt: tm,
+		})
+
+		assert.Equal(t, `time=2020-08-25T19:06:36Z level=unknown msg="line\nnext" my_key_="val\\ue"`, formatted.FormattedMessage)
+	})
+
+	t.Run("with caller", func(t *testing.T) {
+		formatter := NewLogfmtFormatter(time.RFC3339)
+		formatted := formatter.Format(Log{
+			Level:     LevelInfo,
+			Message:   "test",
+			Data:      Data{"key": "val"},
+			CreatedAt: tm,
+			Caller:    &Caller{File: "/app/main.go", Line: 42, Function: "main.main"},
+		})
+
+		assert.Equal(t, "time=2020-08-25T19:06:36Z level=info msg=test caller=/app/main.go:42 func=main.main key=val", formatted.FormattedMessage)
+	})
+
+	t.Run("with fields", func(t *testing.T) {
+		formatter := NewLogfmtFormatter(time.RFC3339)
+		formatted := formatter.Format(Log{
+			Level:     LevelInfo,
+			Message:   "test",
+			Data:      Data{"key": "val"},
+			Fields:    Fields{Duration("took", time.Second), Object("user", String("name", "john"))},
+			CreatedAt: tm,
+		})
+
+		assert.Equal(t, `time=2020-08-25T19:06:36Z level=info msg=test key=val took=1s user={name:john}`, formatted.FormattedMessage)
+	})
+}
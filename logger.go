@@ -1,31 +1,89 @@
 package logger
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
 // MainLogger struct wraps handler "Handle" function
 // into few simple functions for easier log management.
 type MainLogger struct {
-	handler         Handler
-	clock           Clock
-	criticalHandler CriticalHandleFunc
-	failureHandler  FailureHandleFunc
-	config          *config
+	handler  Handler
+	config   *config
+	settings *settingsLayer
+	data     Data
+	name     string
 }
 
 // New creates new *MainLogger instance.
 func New(handler Handler) *MainLogger {
-	return &MainLogger{handler: handler, clock: NewDefaultClock(), config: newConfig(LevelDebug)}
+	return &MainLogger{handler: handler, config: newConfig(LevelDebug), settings: newSettingsLayer(nil)}
 }
 
 // SetLevel changes minimum required Level for Log to be handled (LevelDebug by default - all logs).
+// For logger created with Named it works the same way as SetNamedLevel with its name,
+// so it does not change level of parent loggers.
 func (l *MainLogger) SetLevel(level Level) {
+	if l.name != "" {
+		l.config.setNamedLevel(l.name, level)
+
+		return
+	}
+
 	l.config.setLevel(level)
 }
 
+// SetNamedLevel changes minimum required Level for loggers with provided
+// name (created with Named) and all loggers nested under it (e.g. level set
+// for "db" applies to "db.pool" unless "db.pool" has its own level).
+func (l *MainLogger) SetNamedLevel(name string, level Level) {
+	l.config.setNamedLevel(name, level)
+}
+
+// ResetNamedLevel removes level set for provided name with SetNamedLevel,
+// so logger with this name inherits level from its parent again.
+func (l *MainLogger) ResetNamedLevel(name string) {
+	l.config.resetNamedLevel(name)
+}
+
+// NamedLevels returns all levels set with SetNamedLevel.
+func (l *MainLogger) NamedLevels() map[string]Level {
+	return l.config.getNamedLevels()
+}
+
+// Level returns minimum required Level for Log to be handled by this
+// logger (level set for its name or inherited from parent names).
+func (l *MainLogger) Level() Level {
+	return l.config.getNamedLevel(l.name)
+}
+
+// Named creates child *MainLogger with provided name (stored in Log.Name).
+// Name of named logger child is joined with dot (e.g. "db" and "pool"
+// results in "db.pool"). Child shares handler and levels with its parent
+// and inherits its settings changed with With* functions (also when they are
+// changed after child was created). With* functions called on child change
+// only child and its own children.
+func (l *MainLogger) Named(name string) *MainLogger {
+	child := *l
+
+	if l.name != "" && name != "" {
+		child.name = l.name + "." + name
+	} else {
+		child.name = l.name + name
+	}
+
+	child.settings = newSettingsLayer(l.settings)
+
+	return &child
+}
+
 // WithClock allows to set custom implementation for
 // Clock interface and manage log time with it.
 func (l *MainLogger) WithClock(clock Clock) *MainLogger {
-	l.clock = clock
+	l.settings.update(settingClock, func(s *settings) {
+		s.clock = clock
+	})
 
 	return l
 }
@@ -33,13 +91,92 @@ func (l *MainLogger) WithClock(clock Clock) *MainLogger {
 // WithCriticalHandler sets custom handler for Critical and Criticalf logs.
 // By default no handler is set.
 func (l *MainLogger) WithCriticalHandler(handleFunc CriticalHandleFunc) *MainLogger {
-	l.criticalHandler = handleFunc
+	l.settings.update(settingCriticalHandler, func(s *settings) {
+		s.criticalHandler = handleFunc
+	})
 
 	return l
 }
 
+// WithFailureHandler sets function called when handler returns an error.
+// Error passed to the function is always *HandlerFailure.
 func (l *MainLogger) WithFailureHandler(handleFunc FailureHandleFunc) *MainLogger {
-	l.failureHandler = handleFunc
+	l.settings.update(settingFailureHandler, func(s *settings) {
+		s.failureHandler = handleFunc
+	})
+
+	return l
+}
+
+// WithFallbackHandler sets handler (e.g. StringWriterHandler with os.Stderr)
+// which receives logs rejected by main handler. Logs handled by other handler
+// from the same group and logs dropped on purpose (ErrRateLimited,
+// ErrAsyncHandlerClosed) are not passed to fallback handler.
+func (l *MainLogger) WithFallbackHandler(handler Handler) *MainLogger {
+	l.settings.update(settingFallbackHandler, func(s *settings) {
+		s.fallbackHandler = handler
+	})
+
+	return l
+}
+
+// WithContextExtractor registers function which extracts Data
+// from context.Context provided to *Ctx logging functions.
+// Extractors are executed in order of registration
+// (extractors inherited from parent logger first).
+func (l *MainLogger) WithContextExtractor(extractFunc ContextExtractFunc) *MainLogger {
+	l.settings.update(0, func(s *settings) {
+		s.extractors = append(s.extractors, extractFunc)
+	})
+
+	return l
+}
+
+// With creates child *MainLogger which adds provided values as
+// Data to every Log. Values are pre-bound in the same "key:value"
+// manner as in standard functions and per-call values with the
+// same keys take precedence. Child shares handler and levels with its
+// parent and inherits its settings changed with With* functions (also
+// when they are changed after child was created). With* functions called
+// on child change only child and its own children.
+func (l *MainLogger) With(values ...string) *MainLogger {
+	d := make(Data)
+
+	for key, val := range l.data {
+		d[key] = val
+	}
+
+	for key, val := range createDataFromSlice(values) {
+		d[key] = val
+	}
+
+	child := *l
+	child.data = d
+	child.settings = newSettingsLayer(l.settings)
+
+	return &child
+}
+
+// WithCaller enables capturing file, line and function of the code
+// which created the log (Log.Caller). Logger functions are skipped
+// automatically, "extraSkip" allows to skip additional frames
+// of custom wrappers around logger.
+func (l *MainLogger) WithCaller(extraSkip int) *MainLogger {
+	l.settings.update(settingCaller, func(s *settings) {
+		s.withCaller = true
+		s.callerSkip = extraSkip
+	})
+
+	return l
+}
+
+// WithStackTrace enables capturing stack trace (Log.Stack) for logs
+// with level equal or greater than provided one (e.g. LevelError).
+func (l *MainLogger) WithStackTrace(level Level) *MainLogger {
+	l.settings.update(settingStackTrace, func(s *settings) {
+		s.withStack = true
+		s.stackLevel = level
+	})
 
 	return l
 }
@@ -52,6 +189,14 @@ func (l *MainLogger) Debugf(message string, values ...interface{}) {
 	l.handleFormattedLog(LevelDebug, message, values)
 }
 
+func (l *MainLogger) DebugCtx(ctx context.Context, message string, values ...string) {
+	l.handleContextLog(ctx, LevelDebug, message, values)
+}
+
+func (l *MainLogger) Debugw(message string, fields ...Field) {
+	l.handleFieldsLog(LevelDebug, message, fields)
+}
+
 func (l *MainLogger) Info(message string, values ...string) {
 	l.handleStandardLog(LevelInfo, message, values)
 }
@@ -60,6 +205,14 @@ func (l *MainLogger) Infof(message string, values ...interface{}) {
 	l.handleFormattedLog(LevelInfo, message, values)
 }
 
+func (l *MainLogger) InfoCtx(ctx context.Context, message string, values ...string) {
+	l.handleContextLog(ctx, LevelInfo, message, values)
+}
+
+func (l *MainLogger) Infow(message string, fields ...Field) {
+	l.handleFieldsLog(LevelInfo, message, fields)
+}
+
 func (l *MainLogger) Warning(message string, values ...string) {
 	l.handleStandardLog(LevelWarning, message, values)
 }
@@ -68,6 +221,14 @@ func (l *MainLogger) Warningf(message string, values ...interface{}) {
 	l.handleFormattedLog(LevelWarning, message, values)
 }
 
+func (l *MainLogger) WarningCtx(ctx context.Context, message string, values ...string) {
+	l.handleContextLog(ctx, LevelWarning, message, values)
+}
+
+func (l *MainLogger) Warningw(message string, fields ...Field) {
+	l.handleFieldsLog(LevelWarning, message, fields)
+}
+
 func (l *MainLogger) Error(message string, values ...string) {
 	l.handleStandardLog(LevelError, message, values)
 }
@@ -76,6 +237,14 @@ func (l *MainLogger) Errorf(message string, values ...interface{}) {
 	l.handleFormattedLog(LevelError, message, values)
 }
 
+func (l *MainLogger) ErrorCtx(ctx context.Context, message string, values ...string) {
+	l.handleContextLog(ctx, LevelError, message, values)
+}
+
+func (l *MainLogger) Errorw(message string, fields ...Field) {
+	l.handleFieldsLog(LevelError, message, fields)
+}
+
 func (l *MainLogger) Critical(message string, values ...string) {
 	l.handleWithCritical(l.handleStandardLog(LevelCritical, message, values))
 }
@@ -84,51 +253,171 @@ func (l *MainLogger) Criticalf(message string, values ...interface{}) {
 	l.handleWithCritical(l.handleFormattedLog(LevelCritical, message, values))
 }
 
+func (l *MainLogger) CriticalCtx(ctx context.Context, message string, values ...string) {
+	l.handleWithCritical(l.handleContextLog(ctx, LevelCritical, message, values))
+}
+
+func (l *MainLogger) Criticalw(message string, fields ...Field) {
+	l.handleWithCritical(l.handleFieldsLog(LevelCritical, message, fields))
+}
+
+// Log creates Log with provided level (e.g. custom level registered
+// with RegisterLevel). Logs with LevelCritical or above trigger CriticalHandleFunc.
+func (l *MainLogger) Log(level Level, message string, values ...string) {
+	l.handleWithCritical(l.handleStandardLog(level, message, values))
+}
+
+// Logf works the same way as Log but formats message with fmt.Sprintf.
+func (l *MainLogger) Logf(level Level, message string, values ...interface{}) {
+	l.handleWithCritical(l.handleFormattedLog(level, message, values))
+}
+
 func (l *MainLogger) handleStandardLog(level Level, message string, values []string) (Log, bool) {
-	if !level.EqualOrGreaterThan(l.config.getLevel()) {
+	if !l.isEnabled(level) {
 		return Log{}, false
 	}
 
-	log := l.createLog(level, message, values)
+	log := l.createLog(nil, level, message, values, nil)
 	l.handleError(log, l.handler.Handle(log))
 
 	return log, true
 }
 
 func (l *MainLogger) handleFormattedLog(level Level, message string, values []interface{}) (Log, bool) {
-	if !level.EqualOrGreaterThan(l.config.getLevel()) {
+	if !l.isEnabled(level) {
+		return Log{}, false
+	}
+
+	log := l.createLog(nil, level, fmt.Sprintf(message, values...), []string{}, nil)
+	l.handleError(log, l.handler.Handle(log))
+
+	return log, true
+}
+
+func (l *MainLogger) handleContextLog(ctx context.Context, level Level, message string, values []string) (Log, bool) {
+	if !l.isEnabled(level) {
+		return Log{}, false
+	}
+
+	log := l.createLog(ctx, level, message, values, nil)
+	l.handleError(log, l.handler.Handle(log))
+
+	return log, true
+}
+
+func (l *MainLogger) handleFieldsLog(level Level, message string, fields []Field) (Log, bool) {
+	if !l.isEnabled(level) {
+		return Log{}, false
+	}
+
+	log := l.createLog(nil, level, message, []string{}, nil)
+	log.Fields = fields
+	l.handleError(log, l.handler.Handle(log))
+
+	return log, true
+}
+
+func (l *MainLogger) handleStackTraceLog(level Level, message string, values []string, stack StackTrace) (Log, bool) {
+	if !l.isEnabled(level) {
 		return Log{}, false
 	}
 
-	log := l.createLog(level, fmt.Sprintf(message, values...), []string{})
+	log := l.createLog(nil, level, message, values, stack)
 	l.handleError(log, l.handler.Handle(log))
 
 	return log, true
 }
 
+// logWithStackTrace creates log with provided stack trace instead of
+// captured one. It is used by ErrorWrappedLogger for errors with stack traces.
+func (l *MainLogger) logWithStackTrace(level Level, message string, values []string, stack StackTrace) {
+	l.handleWithCritical(l.handleStackTraceLog(level, message, values, stack))
+}
+
+// handleWithCritical passes handled log with LevelCritical
+// or above to CriticalHandleFunc.
 func (l *MainLogger) handleWithCritical(log Log, isHandling bool) {
-	if isHandling && l.criticalHandler != nil {
-		l.criticalHandler(log.Message, log.Data)
+	if !isHandling || !log.Level.EqualOrGreaterThan(LevelCritical) {
+		return
+	}
+
+	if criticalHandler := l.settings.get().criticalHandler; criticalHandler != nil {
+		criticalHandler(log.Message, log.Data)
 	}
 }
 
 func (l *MainLogger) handleError(log Log, err error) {
-	if l.failureHandler != nil {
-		l.failureHandler(log, err)
+	if err == nil {
+		return
+	}
+
+	s := l.settings.get()
+	failure := &HandlerFailure{Handler: handlerName(l.handler), Index: -1, Attempts: 1, Err: err, Dropped: true}
+	outer := &HandlerFailure{}
+
+	if errors.As(err, &outer) {
+		failure.Handler, failure.Index, failure.Dropped = outer.Handler, outer.Index, outer.Dropped
+		failure.Attempts = outer.innermost().Attempts
+	}
+
+	if failure.Dropped && s.fallbackHandler != nil && !isIntentionalDrop(err) {
+		fErr := s.fallbackHandler.Handle(log)
+
+		if fErr != nil {
+			failure.Err = errors.Join(err, fmt.Errorf("fallback handler returned an error: %w", fErr))
+		} else {
+			failure.Dropped = false
+		}
+	}
+
+	if s.failureHandler != nil {
+		s.failureHandler(log, failure)
 	}
 }
 
-func (l *MainLogger) createLog(level Level, message string, values []string) Log {
+func (l *MainLogger) isEnabled(level Level) bool {
+	return level.EqualOrGreaterThan(l.config.getNamedLevel(l.name))
+}
+
+func (l *MainLogger) createLog(ctx context.Context, level Level, message string, values []string, stack StackTrace) Log {
+	s := l.settings.get()
 	d := make(Data)
 
+	for key, val := range l.data {
+		d[key] = val
+	}
+
+	if ctx != nil {
+		for _, extract := range s.extractors {
+			for key, val := range extract(ctx) {
+				d[key] = val
+			}
+		}
+	}
+
 	if len(values) > 0 {
-		d = createDataFromSlice(values)
+		for key, val := range createDataFromSlice(values) {
+			d[key] = val
+		}
 	}
 
-	return Log{
+	log := Log{
 		Level:     level,
+		Name:      l.name,
 		Message:   message,
 		Data:      d,
-		CreatedAt: l.clock.Now(),
+		CreatedAt: s.clock.Now(),
+		Context:   ctx,
+		Stack:     stack,
+	}
+
+	if s.withCaller {
+		log.Caller = captureCaller(s.callerSkip)
 	}
+
+	if stack == nil && s.withStack && level.EqualOrGreaterThan(s.stackLevel) {
+		log.Stack = captureStackTrace(s.callerSkip)
+	}
+
+	return log
 }
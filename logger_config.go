@@ -0,0 +1,458 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrUnknownHandlerType           = errors.New("handler type is not registered")
+	ErrHandlerTypeAlreadyRegistered = errors.New("handler type is already registered")
+	ErrInvalidConfig                = errors.New("invalid logger config")
+
+	handlerFactoriesLock sync.RWMutex
+	handlerFactories     map[string]HandlerFactory
+)
+
+// Built-in factories are registered in init, because they use BuildHandler
+// for nested handlers and would create initialization cycle in var block.
+func init() {
+	handlerFactories = map[string]HandlerFactory{
+		"file":          buildFileHandler,
+		"stream":        buildStreamHandler,
+		"memory":        buildInMemoryHandler,
+		"level_grouped": buildLevelGroupedHandler,
+		"multi":         buildMultiHandler,
+	}
+}
+
+type (
+	// UnmarshalFunc decodes config document (e.g. json.Unmarshal or yaml.Unmarshal).
+	UnmarshalFunc func(data []byte, v interface{}) error
+	// HandlerFactory creates Handler from config. Factories of handlers
+	// wrapping other handlers can use BuildHandler for nested configs.
+	HandlerFactory func(config HandlerConfig) (Handler, error)
+	// LoggerConfig describes complete MainLogger pipeline:
+	//
+	//	{
+	//	  "level": "info",
+	//	  "named_levels": {"db": "debug"},
+	//	  "handler": {"type": "file", "path": "/var/log/app.log", "formatter": {"type": "basic", "app_name": "app"}}
+	//	}
+	LoggerConfig struct {
+		Level       string            `json:"level" yaml:"level"`
+		NamedLevels map[string]string `json:"named_levels" yaml:"named_levels"`
+		Handler     HandlerConfig     `json:"handler" yaml:"handler"`
+	}
+	// HandlerConfig describes single handler. Type selects factory registered with
+	// RegisterHandlerType, built-in types are:
+	//  * "file" - FileHandler writing to Path,
+	//  * "stream" - StringWriterHandler writing to Output ("stdout" by default or "stderr"),
+	//  * "memory" - InMemoryHandler with BufferSize,
+	//  * "level_grouped" - LevelGroupedHandler with Groups and Fallback handler,
+	//  * "multi" - MultiHandler with Handlers (delivered concurrently with Concurrent).
+	// Options are not used by built-in types and can be used by custom ones.
+	HandlerConfig struct {
+		Type       string                 `json:"type" yaml:"type"`
+		Path       string                 `json:"path,omitempty" yaml:"path,omitempty"`
+		Output     string                 `json:"output,omitempty" yaml:"output,omitempty"`
+		BufferSize uint                   `json:"buffer_size,omitempty" yaml:"buffer_size,omitempty"`
+		Formatter  *FormatterConfig       `json:"formatter,omitempty" yaml:"formatter,omitempty"`
+		Groups     []LevelGroupConfig     `json:"groups,omitempty" yaml:"groups,omitempty"`
+		FirstMatch bool                   `json:"first_match,omitempty" yaml:"first_match,omitempty"`
+		Fallback   *HandlerConfig         `json:"fallback,omitempty" yaml:"fallback,omitempty"`
+		Handlers   []HandlerConfig        `json:"handlers,omitempty" yaml:"handlers,omitempty"`
+		Concurrent bool                   `json:"concurrent,omitempty" yaml:"concurrent,omitempty"`
+		Options    map[string]interface{} `json:"options,omitempty" yaml:"options,omitempty"`
+	}
+	// FormatterConfig describes formatter. Type is one of "basic"
+	// (default, with AppName and DateFormat), "json" or "logfmt" (with DateFormat).
+	FormatterConfig struct {
+		Type       string `json:"type" yaml:"type"`
+		AppName    string `json:"app_name,omitempty" yaml:"app_name,omitempty"`
+		DateFormat string `json:"date_format,omitempty" yaml:"date_format,omitempty"`
+	}
+	// LevelGroupConfig describes group of "level_grouped" handler matching
+	// exact Levels or range between MinLevel and MaxLevel (empty - no limit).
+	LevelGroupConfig struct {
+		Levels   []string      `json:"levels,omitempty" yaml:"levels,omitempty"`
+		MinLevel string        `json:"min_level,omitempty" yaml:"min_level,omitempty"`
+		MaxLevel string        `json:"max_level,omitempty" yaml:"max_level,omitempty"`
+		Handler  HandlerConfig `json:"handler" yaml:"handler"`
+	}
+)
+
+// RegisterHandlerType adds factory for handlers with provided type,
+// so they can be created from HandlerConfig.
+func RegisterHandlerType(name string, factory HandlerFactory) error {
+	handlerFactoriesLock.Lock()
+	defer handlerFactoriesLock.Unlock()
+
+	if _, ok := handlerFactories[name]; ok {
+		return fmt.Errorf("%w: \"%s\"", ErrHandlerTypeAlreadyRegistered, name)
+	}
+
+	handlerFactories[name] = factory
+
+	return nil
+}
+
+// ParseConfig decodes LoggerConfig from JSON document.
+func ParseConfig(data []byte) (*LoggerConfig, error) {
+	return ParseConfigWith(data, json.Unmarshal)
+}
+
+// ParseConfigWith decodes LoggerConfig with provided function
+// (e.g. yaml.Unmarshal from gopkg.in/yaml.v3 for YAML documents).
+func ParseConfigWith(data []byte, unmarshal UnmarshalFunc) (*LoggerConfig, error) {
+	c := &LoggerConfig{}
+	err := unmarshal(data, c)
+
+	if err != nil {
+		return nil, fmt.Errorf("LoggerConfig - unable to decode config: %w", err)
+	}
+
+	return c, nil
+}
+
+// LoadConfigFile reads LoggerConfig from file (JSON when "unmarshal" is nil)
+// and applies LOGGER_* environment variables to it.
+func LoadConfigFile(path string, unmarshal UnmarshalFunc) (*LoggerConfig, error) {
+	return loadConfigFile(NewDefaultFilesystem(), path, unmarshal, os.LookupEnv)
+}
+
+func loadConfigFile(fs Filesystem, path string, unmarshal UnmarshalFunc, lookup func(key string) (string, bool)) (*LoggerConfig, error) {
+	if unmarshal == nil {
+		unmarshal = json.Unmarshal
+	}
+
+	file, oErr := fs.Open(path)
+
+	if oErr != nil {
+		return nil, fmt.Errorf("LoggerConfig - unable to open config file: %w", oErr)
+	}
+
+	defer file.Close()
+
+	data, rErr := ioutil.ReadAll(file)
+
+	if rErr != nil {
+		return nil, fmt.Errorf("LoggerConfig - unable to read config file: %w", rErr)
+	}
+
+	c, pErr := ParseConfigWith(data, unmarshal)
+
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	c.ApplyEnv(lookup)
+
+	return c, nil
+}
+
+// ApplyEnv overrides config with environment variables returned by lookup
+// function (e.g. os.LookupEnv):
+//   - LOGGER_LEVEL - default level,
+//   - LOGGER_NAMED_LEVELS - levels for logger names ("db=debug,http=warning"),
+//   - LOGGER_HANDLER_TYPE, LOGGER_HANDLER_PATH, LOGGER_HANDLER_OUTPUT - main handler,
+//   - LOGGER_FORMATTER_TYPE, LOGGER_FORMATTER_APP_NAME, LOGGER_FORMATTER_DATE_FORMAT - main handler formatter.
+func (c *LoggerConfig) ApplyEnv(lookup func(key string) (string, bool)) {
+	if val, ok := lookup("LOGGER_LEVEL"); ok {
+		c.Level = val
+	}
+
+	if val, ok := lookup("LOGGER_NAMED_LEVELS"); ok {
+		c.NamedLevels = make(map[string]string)
+
+		for _, pair := range strings.Split(val, ",") {
+			parts := strings.SplitN(pair, "=", 2)
+
+			if len(parts) == 2 {
+				c.NamedLevels[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+			}
+		}
+	}
+
+	if val, ok := lookup("LOGGER_HANDLER_TYPE"); ok {
+		c.Handler.Type = val
+	}
+
+	if val, ok := lookup("LOGGER_HANDLER_PATH"); ok {
+		c.Handler.Path = val
+	}
+
+	if val, ok := lookup("LOGGER_HANDLER_OUTPUT"); ok {
+		c.Handler.Output = val
+	}
+
+	if val, ok := lookup("LOGGER_FORMATTER_TYPE"); ok {
+		c.handlerFormatter().Type = val
+	}
+
+	if val, ok := lookup("LOGGER_FORMATTER_APP_NAME"); ok {
+		c.handlerFormatter().AppName = val
+	}
+
+	if val, ok := lookup("LOGGER_FORMATTER_DATE_FORMAT"); ok {
+		c.handlerFormatter().DateFormat = val
+	}
+}
+
+func (c *LoggerConfig) handlerFormatter() *FormatterConfig {
+	if c.Handler.Formatter == nil {
+		c.Handler.Formatter = &FormatterConfig{}
+	}
+
+	return c.Handler.Formatter
+}
+
+// Build creates MainLogger with handler and levels described by config.
+func (c *LoggerConfig) Build() (*MainLogger, error) {
+	handler, hErr := BuildHandler(c.Handler)
+
+	if hErr != nil {
+		return nil, hErr
+	}
+
+	l := New(handler)
+	lErr := c.applyLevels(l)
+
+	if lErr != nil {
+		return nil, lErr
+	}
+
+	return l, nil
+}
+
+// applyLevels sets default level (LevelDebug if empty) and levels
+// for logger names. Names not present in config are reset.
+func (c *LoggerConfig) applyLevels(l *MainLogger) error {
+	level, named, err := c.parseLevels()
+
+	if err != nil {
+		return err
+	}
+
+	setLevels(l, level, named)
+
+	return nil
+}
+
+func (c *LoggerConfig) parseLevels() (Level, map[string]Level, error) {
+	level := LevelDebug
+
+	if c.Level != "" {
+		parsed, err := parseLevel(c.Level)
+
+		if err != nil {
+			return 0, nil, fmt.Errorf("LoggerConfig - invalid level: %w", err)
+		}
+
+		level = parsed
+	}
+
+	named := make(map[string]Level, len(c.NamedLevels))
+
+	for name, val := range c.NamedLevels {
+		parsed, err := parseLevel(val)
+
+		if err != nil {
+			return 0, nil, fmt.Errorf("LoggerConfig - invalid level for \"%s\": %w", name, err)
+		}
+
+		named[name] = parsed
+	}
+
+	return level, named, nil
+}
+
+func setLevels(l *MainLogger, level Level, named map[string]Level) {
+	l.SetLevel(level)
+
+	for name := range l.NamedLevels() {
+		if _, ok := named[name]; !ok {
+			l.ResetNamedLevel(name)
+		}
+	}
+
+	for name, val := range named {
+		l.SetNamedLevel(name, val)
+	}
+}
+
+// BuildHandler creates Handler with factory registered for config type.
+func BuildHandler(config HandlerConfig) (Handler, error) {
+	handlerFactoriesLock.RLock()
+	factory, ok := handlerFactories[config.Type]
+	handlerFactoriesLock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("LoggerConfig - unable to build handler: %w: \"%s\"", ErrUnknownHandlerType, config.Type)
+	}
+
+	return factory(config)
+}
+
+// BuildFormatter creates Formatter described by config.
+func BuildFormatter(config FormatterConfig) (Formatter, error) {
+	switch config.Type {
+	case "", "basic":
+		return NewBasicFormatter(config.AppName, config.DateFormat), nil
+	case "json":
+		f := NewJSONFormatter()
+
+		if config.DateFormat != "" {
+			f.WithTimeLayout(config.DateFormat)
+		}
+
+		return f, nil
+	case "logfmt":
+		return NewLogfmtFormatter(config.DateFormat), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown formatter type \"%s\"", ErrInvalidConfig, config.Type)
+	}
+}
+
+func buildFileHandler(config HandlerConfig) (Handler, error) {
+	if config.Path == "" {
+		return nil, fmt.Errorf("%w: file handler requires path", ErrInvalidConfig)
+	}
+
+	h := NewFileHandler(config.Path)
+
+	if config.Formatter != nil {
+		f, err := BuildFormatter(*config.Formatter)
+
+		if err != nil {
+			return nil, err
+		}
+
+		h.UseFormatter(f)
+	}
+
+	return h, nil
+}
+
+func buildStreamHandler(config HandlerConfig) (Handler, error) {
+	var h *StringWriterHandler
+
+	switch config.Output {
+	case "", "stdout":
+		h = NewStringWriterHandler(os.Stdout)
+	case "stderr":
+		h = NewStringWriterHandler(os.Stderr)
+	default:
+		return nil, fmt.Errorf("%w: unknown stream output \"%s\"", ErrInvalidConfig, config.Output)
+	}
+
+	if config.Formatter != nil {
+		f, err := BuildFormatter(*config.Formatter)
+
+		if err != nil {
+			return nil, err
+		}
+
+		h.UseFormatter(f)
+	}
+
+	return h, nil
+}
+
+func buildInMemoryHandler(config HandlerConfig) (Handler, error) {
+	return NewInMemoryHandler(config.BufferSize), nil
+}
+
+func buildLevelGroupedHandler(config HandlerConfig) (Handler, error) {
+	if config.Fallback == nil {
+		return nil, fmt.Errorf("%w: level grouped handler requires fallback handler", ErrInvalidConfig)
+	}
+
+	fallback, fErr := BuildHandler(*config.Fallback)
+
+	if fErr != nil {
+		return nil, fErr
+	}
+
+	h := NewLevelGroupedHandler(fallback)
+
+	if config.FirstMatch {
+		h.WithFirstMatchOnly()
+	}
+
+	for _, group := range config.Groups {
+		handler, hErr := BuildHandler(group.Handler)
+
+		if hErr != nil {
+			return nil, hErr
+		}
+
+		if len(group.Levels) > 0 {
+			levels := make([]Level, 0, len(group.Levels))
+
+			for _, val := range group.Levels {
+				level, lErr := parseLevel(val)
+
+				if lErr != nil {
+					return nil, fmt.Errorf("LoggerConfig - invalid group level: %w", lErr)
+				}
+
+				levels = append(levels, level)
+			}
+
+			h.createHandlersList([]LevelGroup{{Levels: levels, Handler: handler}})
+
+			continue
+		}
+
+		rangeGroup := LevelRangeGroup{MinLevel: LevelDebug, MaxLevel: LevelMax, Handler: handler}
+
+		for _, bound := range []struct {
+			value string
+			level *Level
+		}{{group.MinLevel, &rangeGroup.MinLevel}, {group.MaxLevel, &rangeGroup.MaxLevel}} {
+			if bound.value == "" {
+				continue
+			}
+
+			level, lErr := parseLevel(bound.value)
+
+			if lErr != nil {
+				return nil, fmt.Errorf("LoggerConfig - invalid group level: %w", lErr)
+			}
+
+			*bound.level = level
+		}
+
+		h.WithRangeGroups(rangeGroup)
+	}
+
+	return h, nil
+}
+
+func buildMultiHandler(config HandlerConfig) (Handler, error) {
+	handlers := make([]Handler, 0, len(config.Handlers))
+
+	for _, hc := range config.Handlers {
+		handler, err := BuildHandler(hc)
+
+		if err != nil {
+			return nil, err
+		}
+
+		handlers = append(handlers, handler)
+	}
+
+	h := NewMultiHandler(handlers...)
+
+	if config.Concurrent {
+		h.WithConcurrentDelivery()
+	}
+
+	return h, nil
+}
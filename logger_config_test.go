@@ -0,0 +1,206 @@
+package logger
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	configTestHandlersLock sync.Mutex
+	configTestHandlers     = make(map[string]*InMemoryHandler)
+)
+
+func init() {
+	// "config_test" handler type returns the same in-memory
+	// handler for the same "id" option, so tests can check routing.
+	_ = RegisterHandlerType("config_test", func(config HandlerConfig) (Handler, error) {
+		id, _ := config.Options["id"].(string)
+
+		return configTestHandler(id), nil
+	})
+}
+
+func configTestHandler(id string) *InMemoryHandler {
+	configTestHandlersLock.Lock()
+	defer configTestHandlersLock.Unlock()
+
+	if _, ok := configTestHandlers[id]; !ok {
+		configTestHandlers[id] = NewInMemoryHandler(0)
+	}
+
+	return configTestHandlers[id]
+}
+
+// resetConfigTestHandlers removes state left by previous runs of the same test.
+func resetConfigTestHandlers(ids ...string) {
+	configTestHandlersLock.Lock()
+	defer configTestHandlersLock.Unlock()
+
+	for _, id := range ids {
+		delete(configTestHandlers, id)
+		closedTestHandlers.Delete(id)
+	}
+}
+
+func TestParseConfig_Build(t *testing.T) {
+	t.Parallel()
+	resetConfigTestHandlers("build-alerts", "build-info", "build-fallback")
+
+	c, pErr := ParseConfig([]byte(`{
+		"level": "info",
+		"named_levels": {"db": "debug"},
+		"handler": {
+			"type": "level_grouped",
+			"groups": [
+				{"min_level": "warning", "handler": {"type": "config_test", "options": {"id": "build-alerts"}}},
+				{"levels": ["info"], "handler": {"type": "config_test", "options": {"id": "build-info"}}}
+			],
+			"fallback": {"type": "config_test", "options": {"id": "build-fallback"}}
+		}
+	}`))
+
+	if !assert.NoError(t, pErr) {
+		return
+	}
+
+	l, bErr := c.Build()
+
+	if !assert.NoError(t, bErr) {
+		return
+	}
+
+	l.Debug("skipped")
+	l.Named("db.pool").Debug("db debug")
+	l.Info("info")
+	l.Log(Level(5000), "custom")
+	l.Critical("critical")
+
+	assert.Equal(t, "db debug", configTestHandler("build-fallback").Pop().Message)
+	assert.True(t, configTestHandler("build-fallback").IsEmpty())
+	assert.Equal(t, "info", configTestHandler("build-info").Pop().Message)
+	assert.Equal(t, "critical", configTestHandler("build-alerts").Pop().Message)
+	assert.Equal(t, "custom", configTestHandler("build-alerts").Pop().Message)
+	assert.True(t, configTestHandler("build-alerts").IsEmpty())
+}
+
+func TestParseConfig_Build_BuiltInHandlers(t *testing.T) {
+	t.Parallel()
+
+	c, pErr := ParseConfig([]byte(`{
+		"handler": {
+			"type": "multi",
+			"concurrent": true,
+			"handlers": [
+				{"type": "file", "path": "/tmp/app.log", "formatter": {"type": "json"}},
+				{"type": "stream", "output": "stderr", "formatter": {"type": "basic", "app_name": "app", "date_format": "2006"}},
+				{"type": "memory", "buffer_size": 10}
+			]
+		}
+	}`))
+
+	if !assert.NoError(t, pErr) {
+		return
+	}
+
+	handler, hErr := BuildHandler(c.Handler)
+
+	if assert.NoError(t, hErr) {
+		assert.IsType(t, &MultiHandler{}, handler)
+		assert.Len(t, handler.(*MultiHandler).handlers, 3)
+		assert.IsType(t, &FileHandler{}, handler.(*MultiHandler).handlers[0])
+		assert.IsType(t, &StringWriterHandler{}, handler.(*MultiHandler).handlers[1])
+		assert.IsType(t, &InMemoryHandler{}, handler.(*MultiHandler).handlers[2])
+	}
+}
+
+func TestParseConfig_Build_Failure(t *testing.T) {
+	t.Parallel()
+
+	expected := map[string]string{
+		`{"handler": {"type": "unknown"}}`:                            "LoggerConfig - unable to build handler: handler type is not registered: \"unknown\"",
+		`{"handler": {"type": "file"}}`:                               "invalid logger config: file handler requires path",
+		`{"handler": {"type": "stream", "output": "x"}}`:              "invalid logger config: unknown stream output \"x\"",
+		`{"handler": {"type": "stream", "formatter": {"type": "x"}}}`: "invalid logger config: unknown formatter type \"x\"",
+		`{"handler": {"type": "level_grouped"}}`:                      "invalid logger config: level grouped handler requires fallback handler",
+		`{"handler": {"type": "level_grouped", "fallback": {"type": "memory"}, "groups": [{"levels": ["x"], "handler": {"type": "memory"}}]}}`: "LoggerConfig - invalid group level: level cannot be mapped to level name: \"x\"",
+		`{"level": "x", "handler": {"type": "memory"}}`:                "LoggerConfig - invalid level: level cannot be mapped to level name: \"x\"",
+		`{"named_levels": {"db": "x"}, "handler": {"type": "memory"}}`: "LoggerConfig - invalid level for \"db\": level cannot be mapped to level name: \"x\"",
+	}
+
+	for config, message := range expected {
+		c, pErr := ParseConfig([]byte(config))
+
+		if !assert.NoError(t, pErr, config) {
+			continue
+		}
+
+		_, bErr := c.Build()
+		assert.EqualError(t, bErr, message, config)
+	}
+
+	_, pErr := ParseConfig([]byte(`{`))
+	assert.EqualError(t, pErr, "LoggerConfig - unable to decode config: unexpected end of JSON input")
+
+	rErr := RegisterHandlerType("memory", func(config HandlerConfig) (Handler, error) {
+		return nil, nil
+	})
+
+	assert.True(t, errors.Is(rErr, ErrHandlerTypeAlreadyRegistered))
+}
+
+func TestLoggerConfig_ApplyEnv(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		"LOGGER_LEVEL":                 "error",
+		"LOGGER_NAMED_LEVELS":          "db=debug, http = warning,invalid",
+		"LOGGER_HANDLER_TYPE":          "file",
+		"LOGGER_HANDLER_PATH":          "/var/log/app.log",
+		"LOGGER_FORMATTER_TYPE":        "logfmt",
+		"LOGGER_FORMATTER_DATE_FORMAT": time.RFC3339,
+	}
+
+	c := &LoggerConfig{Level: "info", Handler: HandlerConfig{Type: "stream", Output: "stderr"}}
+	c.ApplyEnv(func(key string) (string, bool) {
+		val, ok := env[key]
+
+		return val, ok
+	})
+
+	assert.Equal(t, &LoggerConfig{
+		Level:       "error",
+		NamedLevels: map[string]string{"db": "debug", "http": "warning"},
+		Handler: HandlerConfig{
+			Type:      "file",
+			Path:      "/var/log/app.log",
+			Output:    "stderr",
+			Formatter: &FormatterConfig{Type: "logfmt", DateFormat: time.RFC3339},
+		},
+	}, c)
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	t.Parallel()
+
+	fs := newMemoryFilesystem(NewDefaultClock())
+	f, _ := fs.OpenFile("/etc/app/logger.json", 0, 0)
+	_, _ = f.WriteString(`{"level": "info", "handler": {"type": "memory"}}`)
+
+	c, lErr := loadConfigFile(fs, "/etc/app/logger.json", nil, func(key string) (string, bool) {
+		if key == "LOGGER_LEVEL" {
+			return "warning", true
+		}
+
+		return "", false
+	})
+
+	if assert.NoError(t, lErr) {
+		assert.Equal(t, &LoggerConfig{Level: "warning", Handler: HandlerConfig{Type: "memory"}}, c)
+	}
+
+	_, lErr = loadConfigFile(fs, "/etc/app/missing.json", nil, nil)
+	assert.EqualError(t, lErr, "LoggerConfig - unable to open config file: open /etc/app/missing.json: file does not exist")
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -150,6 +151,41 @@ func TestLogger_Criticalf_WithCustomHandler(t *testing.T) {
 	mHandler.AssertExpectations(t)
 }
 
+func TestLogger_Log_WithCustomLevel(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, RegisterLevel(Level(5100), "audit"))
+
+	handler := NewInMemoryHandler(0)
+	logger := New(handler)
+	logger.SetLevel(LevelError)
+
+	logger.Log(Level(5100), "test", "key", "val")
+	logger.Logf(Level(1100), "test %s", "skipped")
+
+	log := handler.Pop()
+	assert.Equal(t, Level(5100), log.Level)
+	assert.Equal(t, "test", log.Message)
+	assert.Equal(t, Data{"key": "val"}, log.Data)
+	assert.True(t, handler.IsEmpty())
+}
+
+func TestLogger_Logf_WithCustomHandler(t *testing.T) {
+	t.Parallel()
+
+	res := ""
+	logger := New(NewInMemoryHandler(0))
+	logger.WithCriticalHandler(func(message string, _ Data) {
+		res = message
+	})
+
+	logger.Logf(LevelError, "test %s", "error")
+	assert.Empty(t, res)
+
+	logger.Logf(Level(10000), "test %s", "emergency")
+	assert.Equal(t, "test emergency", res)
+}
+
 func TestLogger_Log_WithHandlerFailure(t *testing.T) {
 	t.Parallel()
 
@@ -329,3 +365,433 @@ func testLoggerLogfWithFormatting(
 	mClock.AssertExpectations(t)
 	mHandler.AssertExpectations(t)
 }
+
+func TestLogger_With(t *testing.T) {
+	t.Parallel()
+
+	tm := time.Now()
+
+	mClock := &mockClock{}
+	mClock.On("Now").Return(tm)
+
+	mHandler := &mockHandler{}
+	mHandler.On("Handle", Log{
+		Level:     LevelInfo,
+		Message:   "test message",
+		Data:      Data{"request_id": "abc", "tenant": "second", "key": "val"},
+		CreatedAt: tm,
+	}).Return(nil)
+	mHandler.On("Handle", Log{
+		Level:     LevelError,
+		Message:   "test parent",
+		Data:      Data{},
+		CreatedAt: tm,
+	}).Return(nil)
+
+	logger := New(mHandler)
+	logger.WithClock(mClock)
+
+	child := logger.With("request_id", "abc", "tenant", "first")
+	logger.SetLevel(LevelInfo)
+
+	child.Debug("test debug")
+	child.Info("test message", "tenant", "second", "key", "val")
+	logger.Error("test parent")
+
+	mClock.AssertExpectations(t)
+	mHandler.AssertExpectations(t)
+	mHandler.AssertNumberOfCalls(t, "Handle", 2)
+}
+
+func TestLogger_With_Nested(t *testing.T) {
+	t.Parallel()
+
+	tm := time.Now()
+
+	mClock := &mockClock{}
+	mClock.On("Now").Return(tm)
+
+	mHandler := &mockHandler{}
+	mHandler.On("Handle", Log{
+		Level:     LevelWarning,
+		Message:   "test message",
+		Data:      Data{"request_id": "abc", "tenant": "second"},
+		CreatedAt: tm,
+	}).Return(nil)
+
+	logger := New(mHandler)
+	logger.WithClock(mClock)
+
+	logger.With("request_id", "abc", "tenant", "first").With("tenant", "second").Warningf("test %s", "message")
+
+	mClock.AssertExpectations(t)
+	mHandler.AssertExpectations(t)
+}
+
+func TestLogger_LogCtx(t *testing.T) {
+	t.Parallel()
+
+	tm := time.Now()
+	ctx := ContextWithData(context.Background(), "request_id", "abc", "user_id", "42")
+
+	levels := map[Level]func(logger *MainLogger){
+		LevelDebug: func(logger *MainLogger) {
+			logger.DebugCtx(ctx, "test message", "key", "val", "user_id", "43")
+		},
+		LevelInfo: func(logger *MainLogger) {
+			logger.InfoCtx(ctx, "test message", "key", "val", "user_id", "43")
+		},
+		LevelWarning: func(logger *MainLogger) {
+			logger.WarningCtx(ctx, "test message", "key", "val", "user_id", "43")
+		},
+		LevelError: func(logger *MainLogger) {
+			logger.ErrorCtx(ctx, "test message", "key", "val", "user_id", "43")
+		},
+		LevelCritical: func(logger *MainLogger) {
+			logger.CriticalCtx(ctx, "test message", "key", "val", "user_id", "43")
+		},
+	}
+
+	for level, callback := range levels {
+		mClock := &mockClock{}
+		mClock.On("Now").Return(tm)
+
+		mHandler := &mockHandler{}
+		mHandler.On("Handle", Log{
+			Level:     level,
+			Message:   "test message",
+			Data:      Data{"request_id": "abc", "user_id": "43", "key": "val", "trace_id": "xyz"},
+			CreatedAt: tm,
+			Context:   ctx,
+		}).Return(nil)
+
+		logger := New(mHandler)
+		logger.WithClock(mClock)
+		logger.WithContextExtractor(ExtractContextData)
+		logger.WithContextExtractor(func(ctx context.Context) Data {
+			return Data{"trace_id": "xyz"}
+		})
+
+		callback(logger)
+
+		mClock.AssertExpectations(t)
+		mHandler.AssertExpectations(t)
+	}
+}
+
+func TestLogger_LogCtx_WithoutExtractors(t *testing.T) {
+	t.Parallel()
+
+	tm := time.Now()
+	ctx := ContextWithData(context.Background(), "request_id", "abc")
+
+	mClock := &mockClock{}
+	mClock.On("Now").Return(tm)
+
+	mHandler := &mockHandler{}
+	mHandler.On("Handle", Log{
+		Level:     LevelInfo,
+		Message:   "test message",
+		Data:      Data{},
+		CreatedAt: tm,
+		Context:   ctx,
+	}).Return(nil)
+
+	logger := New(mHandler)
+	logger.WithClock(mClock)
+
+	logger.InfoCtx(ctx, "test message")
+
+	mClock.AssertExpectations(t)
+	mHandler.AssertExpectations(t)
+}
+
+func TestLogger_With_SharedSettings(t *testing.T) {
+	t.Parallel()
+
+	tm := time.Now()
+
+	mClock := &mockClock{}
+	mClock.On("Now").Return(tm)
+
+	mHandler := &mockHandler{}
+	mHandler.On("Handle", mock.IsType(Log{})).Return(errors.New("test"))
+
+	var criticals, failures []string
+
+	logger := New(mHandler)
+	children := []*MainLogger{logger.With("key", "val"), logger.Named("db")}
+
+	logger.WithClock(mClock).WithCaller(0).
+		WithCriticalHandler(func(message string, _ Data) {
+			criticals = append(criticals, message)
+		}).
+		WithFailureHandler(func(log Log, _ error) {
+			assert.Equal(t, tm, log.CreatedAt)
+			assert.NotNil(t, log.Caller)
+			failures = append(failures, log.Message)
+		})
+
+	for _, child := range children {
+		child.Critical("test child")
+	}
+
+	assert.Equal(t, []string{"test child", "test child"}, criticals)
+	assert.Equal(t, []string{"test child", "test child"}, failures)
+}
+
+func TestLogger_With_ChildSettings(t *testing.T) {
+	t.Parallel()
+
+	var criticals []string
+
+	handler := NewInMemoryHandler(0)
+	logger := New(handler)
+	child := logger.With("key", "val")
+	sibling := logger.Named("db")
+	grandchild := child.Named("nested")
+
+	child.WithCriticalHandler(func(message string, _ Data) {
+		criticals = append(criticals, message)
+	}).WithContextExtractor(func(ctx context.Context) Data {
+		return Data{"child": "yes"}
+	})
+	logger.WithContextExtractor(func(ctx context.Context) Data {
+		return Data{"root": "yes", "child": "no"}
+	})
+
+	logger.Critical("root")
+	sibling.Critical("sibling")
+	child.Critical("child")
+	grandchild.Critical("grandchild")
+
+	assert.Equal(t, []string{"child", "grandchild"}, criticals)
+
+	ctx := context.Background()
+
+	handler.Clear()
+	logger.InfoCtx(ctx, "root")
+	assert.Equal(t, Data{"root": "yes", "child": "no"}, handler.Pop().Data)
+
+	grandchild.InfoCtx(ctx, "grandchild")
+	assert.Equal(t, Data{"key": "val", "root": "yes", "child": "yes"}, handler.Pop().Data)
+}
+
+func TestLogger_Named(t *testing.T) {
+	t.Parallel()
+
+	handler := NewInMemoryHandler(0)
+	logger := New(handler)
+	logger.SetLevel(LevelInfo)
+	logger.SetNamedLevel("db", LevelDebug)
+	logger.SetNamedLevel("db.pool", LevelError)
+
+	db := logger.Named("db")
+	pool := db.Named("pool")
+	http := logger.Named("http")
+
+	assert.Equal(t, LevelInfo, logger.Level())
+	assert.Equal(t, LevelDebug, db.Level())
+	assert.Equal(t, LevelError, pool.Level())
+	assert.Equal(t, LevelInfo, http.Level())
+
+	http.Debug("skipped")
+	pool.Warning("skipped")
+	db.Debug("test db")
+	pool.Error("test pool")
+
+	log := handler.Pop()
+	assert.Equal(t, "db.pool", log.Name)
+	assert.Equal(t, "test pool", log.Message)
+
+	log = handler.Pop()
+	assert.Equal(t, "db", log.Name)
+	assert.Equal(t, "test db", log.Message)
+	assert.True(t, handler.IsEmpty())
+
+	logger.ResetNamedLevel("db.pool")
+	pool.Debug("test pool")
+
+	assert.Equal(t, "test pool", handler.Pop().Message)
+	assert.Equal(t, map[string]Level{"db": LevelDebug}, logger.NamedLevels())
+}
+
+func TestLogger_Named_SetLevel(t *testing.T) {
+	t.Parallel()
+
+	handler := NewInMemoryHandler(0)
+	logger := New(handler)
+	db := logger.Named("db")
+
+	db.SetLevel(LevelError)
+
+	assert.Equal(t, LevelError, db.Level())
+	assert.Equal(t, LevelDebug, logger.Level())
+	assert.Equal(t, map[string]Level{"db": LevelError}, logger.NamedLevels())
+
+	db.Info("skipped")
+	logger.Info("test")
+
+	assert.Equal(t, "test", handler.Pop().Message)
+	assert.True(t, handler.IsEmpty())
+}
+
+func TestLogger_WithFailureHandler_Success(t *testing.T) {
+	t.Parallel()
+
+	executed := false
+	logger := New(NewInMemoryHandler(0)).WithFailureHandler(func(log Log, err error) {
+		executed = true
+	})
+
+	logger.Info("test")
+
+	assert.False(t, executed)
+}
+
+func TestLogger_WithFailureHandler_HandlerFailure(t *testing.T) {
+	t.Parallel()
+
+	var failure *HandlerFailure
+
+	mHandler := &mockHandler{}
+	mHandler.On("Handle", mock.IsType(Log{})).Return(errors.New("disk full"))
+
+	logger := New(NewMultiHandler(NewInMemoryHandler(0), mHandler)).WithFailureHandler(func(log Log, err error) {
+		assert.True(t, errors.As(err, &failure))
+	})
+
+	logger.Info("test")
+
+	if assert.NotNil(t, failure) {
+		assert.Equal(t, "MultiHandler", failure.Handler)
+		assert.Equal(t, 1, failure.Index)
+		assert.Equal(t, 1, failure.Attempts)
+		assert.False(t, failure.Dropped)
+		assert.EqualError(t, failure, "MultiHandler - handler 1 returned an error: disk full")
+	}
+}
+
+func TestLogger_WithFailureHandler_NestedHandlerFailure(t *testing.T) {
+	t.Parallel()
+
+	var failure *HandlerFailure
+
+	mHandler := &mockHandler{}
+	mHandler.On("Handle", mock.IsType(Log{})).Return(errors.New("disk full"))
+
+	retry := NewRetryHandler(mHandler, 3, time.Millisecond, time.Millisecond).WithSleeper(func(time.Duration) {})
+	logger := New(NewMultiHandler(retry)).WithFailureHandler(func(log Log, err error) {
+		failure = err.(*HandlerFailure)
+	})
+
+	logger.Info("test")
+
+	if assert.NotNil(t, failure) {
+		assert.Equal(t, "MultiHandler", failure.Handler)
+		assert.Equal(t, 0, failure.Index)
+		assert.Equal(t, 3, failure.Attempts)
+		assert.True(t, failure.Dropped)
+	}
+}
+
+func TestLogger_WithFailureHandler_ReloadableHandlerName(t *testing.T) {
+	t.Parallel()
+
+	var failure *HandlerFailure
+
+	mHandler := &mockHandler{}
+	mHandler.On("Handle", mock.IsType(Log{})).Return(errors.New("test"))
+
+	logger := New(&reloadableHandler{handler: mHandler}).WithFailureHandler(func(log Log, err error) {
+		failure = err.(*HandlerFailure)
+	})
+
+	logger.Info("test")
+
+	if assert.NotNil(t, failure) {
+		assert.Equal(t, "*logger.mockHandler", failure.Handler)
+	}
+}
+
+func TestLogger_WithFallbackHandler(t *testing.T) {
+	t.Parallel()
+
+	var failures []*HandlerFailure
+
+	mHandler := &mockHandler{}
+	mHandler.On("Handle", mock.IsType(Log{})).Return(errors.New("test"))
+
+	fallback := NewInMemoryHandler(1)
+	logger := New(mHandler).WithFallbackHandler(fallback).WithFailureHandler(func(log Log, err error) {
+		failures = append(failures, err.(*HandlerFailure))
+	})
+
+	logger.Info("first")
+	logger.Info("second")
+
+	assert.Equal(t, "first", fallback.Pop().Message)
+
+	if assert.Len(t, failures, 2) {
+		assert.Equal(t, "*logger.mockHandler", failures[0].Handler)
+		assert.Equal(t, -1, failures[0].Index)
+		assert.False(t, failures[0].Dropped)
+		assert.EqualError(t, failures[0], "test")
+
+		assert.True(t, failures[1].Dropped)
+		assert.EqualError(t, failures[1], "test\nfallback handler returned an error: InMemoryHandler - number of logs exceeded buffer limit (1 records)")
+	}
+}
+
+func TestLogger_WithFallbackHandler_PartialMultiHandlerFailure(t *testing.T) {
+	t.Parallel()
+
+	var failures []*HandlerFailure
+
+	mHandler := &mockHandler{}
+	mHandler.On("Handle", mock.IsType(Log{})).Return(errors.New("test"))
+
+	handler := NewInMemoryHandler(0)
+	fallback := NewInMemoryHandler(0)
+	logger := New(NewMultiHandler(handler, mHandler)).WithFallbackHandler(fallback).WithFailureHandler(func(log Log, err error) {
+		failures = append(failures, err.(*HandlerFailure))
+	})
+
+	logger.Info("test")
+
+	assert.Equal(t, "test", handler.Pop().Message)
+	assert.True(t, fallback.IsEmpty())
+
+	if assert.Len(t, failures, 1) {
+		assert.Equal(t, 1, failures[0].Index)
+		assert.False(t, failures[0].Dropped)
+	}
+}
+
+func TestLogger_WithFallbackHandler_RateLimited(t *testing.T) {
+	t.Parallel()
+
+	dropped := 0
+	tm := time.Now()
+
+	mClock := &mockClock{}
+	mClock.On("Now").Return(tm)
+
+	handler := NewInMemoryHandler(0)
+	fallback := NewInMemoryHandler(0)
+	limited := NewRateLimitedHandler(handler, map[Level]RateLimit{LevelInfo: {Rate: 1, Burst: 1}}).WithClock(mClock)
+	logger := New(limited).WithFallbackHandler(fallback).WithFailureHandler(func(log Log, err error) {
+		if assert.True(t, errors.Is(err, ErrRateLimited)) && err.(*HandlerFailure).Dropped {
+			dropped++
+		}
+	})
+
+	for i := 0; i < 100; i++ {
+		logger.Info("test")
+	}
+
+	assert.Equal(t, "test", handler.Pop().Message)
+	assert.True(t, handler.IsEmpty())
+	assert.True(t, fallback.IsEmpty())
+	assert.Equal(t, 99, dropped)
+}
@@ -1,8 +1,14 @@
 package logger
 
 import (
+	"bytes"
 	"github.com/stretchr/testify/mock"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
+	"sync"
 	"time"
 )
 
@@ -34,6 +40,18 @@ func (f *mockFormatter) Format(log Log) FormattedLog {
 	return f.Called(log).Get(0).(FormattedLog)
 }
 
+type mockCompressor struct {
+	mock.Mock
+}
+
+func (c *mockCompressor) Compress(dst io.Writer, src io.Reader) error {
+	return c.Called(dst, src).Error(0)
+}
+
+func (c *mockCompressor) Extension() string {
+	return c.Called().String(0)
+}
+
 type mockStringWriter struct {
 	mock.Mock
 }
@@ -48,11 +66,37 @@ type mockFilesystem struct {
 	mock.Mock
 }
 
+func (m *mockFilesystem) Open(name string) (io.ReadCloser, error) {
+	args := m.Called(name)
+	rc, _ := args.Get(0).(io.ReadCloser)
+	return rc, args.Error(1)
+}
+
 func (m *mockFilesystem) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
 	args := m.Called(name, flag, perm)
 	return args.Get(0).(File), args.Error(1)
 }
 
+func (m *mockFilesystem) Rename(oldPath, newPath string) error {
+	return m.Called(oldPath, newPath).Error(0)
+}
+
+func (m *mockFilesystem) Stat(name string) (os.FileInfo, error) {
+	args := m.Called(name)
+	info, _ := args.Get(0).(os.FileInfo)
+	return info, args.Error(1)
+}
+
+func (m *mockFilesystem) Remove(name string) error {
+	return m.Called(name).Error(0)
+}
+
+func (m *mockFilesystem) ReadDir(dirname string) ([]os.FileInfo, error) {
+	args := m.Called(dirname)
+	infos, _ := args.Get(0).([]os.FileInfo)
+	return infos, args.Error(1)
+}
+
 type mockFile struct {
 	mock.Mock
 }
@@ -70,3 +114,223 @@ func (m *mockFile) Write(p []byte) (n int, err error) {
 func (m *mockFile) Close() error {
 	return m.Called().Error(0)
 }
+
+func (m *mockFile) Sync() error {
+	return m.Called().Error(0)
+}
+
+// memoryFilesystem is simple in-memory Filesystem
+// implementation for tests which require real file operations.
+type memoryFilesystem struct {
+	lock  sync.Mutex
+	clock Clock
+	files map[string]*memoryFile
+}
+
+func newMemoryFilesystem(clock Clock) *memoryFilesystem {
+	return &memoryFilesystem{clock: clock, files: make(map[string]*memoryFile)}
+}
+
+func (fs *memoryFilesystem) Open(name string) (io.ReadCloser, error) {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	f, ok := fs.files[name]
+
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+
+	return ioutil.NopCloser(bytes.NewReader(f.content.Bytes())), nil
+}
+
+func (fs *memoryFilesystem) OpenFile(name string, flag int, _ os.FileMode) (File, error) {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	f, ok := fs.files[name]
+
+	if !ok || flag&os.O_TRUNC != 0 {
+		f = &memoryFile{fs: fs, name: filepath.Base(name), modTime: fs.clock.Now()}
+		fs.files[name] = f
+	}
+
+	return f, nil
+}
+
+func (fs *memoryFilesystem) Rename(oldPath, newPath string) error {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	f, ok := fs.files[oldPath]
+
+	if !ok {
+		return &os.PathError{Op: "rename", Path: oldPath, Err: os.ErrNotExist}
+	}
+
+	delete(fs.files, oldPath)
+	f.name = filepath.Base(newPath)
+	fs.files[newPath] = f
+
+	return nil
+}
+
+func (fs *memoryFilesystem) Stat(name string) (os.FileInfo, error) {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	f, ok := fs.files[name]
+
+	if !ok {
+		return nil, &os.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
+	}
+
+	return f.info(), nil
+}
+
+func (fs *memoryFilesystem) Remove(name string) error {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	if _, ok := fs.files[name]; !ok {
+		return &os.PathError{Op: "remove", Path: name, Err: os.ErrNotExist}
+	}
+
+	delete(fs.files, name)
+
+	return nil
+}
+
+func (fs *memoryFilesystem) ReadDir(dirname string) ([]os.FileInfo, error) {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	var infos []os.FileInfo
+
+	for name, f := range fs.files {
+		if filepath.Dir(name) == dirname {
+			infos = append(infos, f.info())
+		}
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name() < infos[j].Name()
+	})
+
+	return infos, nil
+}
+
+func (fs *memoryFilesystem) content(name string) (string, bool) {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	f, ok := fs.files[name]
+
+	if !ok {
+		return "", false
+	}
+
+	return f.content.String(), true
+}
+
+func (fs *memoryFilesystem) names() []string {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	var names []string
+
+	for name := range fs.files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+type memoryFile struct {
+	fs      *memoryFilesystem
+	name    string
+	content bytes.Buffer
+	modTime time.Time
+}
+
+func (f *memoryFile) WriteString(s string) (n int, err error) {
+	return f.Write([]byte(s))
+}
+
+func (f *memoryFile) Write(p []byte) (n int, err error) {
+	f.fs.lock.Lock()
+	defer f.fs.lock.Unlock()
+
+	f.modTime = f.fs.clock.Now()
+
+	return f.content.Write(p)
+}
+
+func (f *memoryFile) Close() error {
+	return nil
+}
+
+func (f *memoryFile) Sync() error {
+	return nil
+}
+
+func (f *memoryFile) info() os.FileInfo {
+	return &memoryFileInfo{name: f.name, size: int64(f.content.Len()), modTime: f.modTime}
+}
+
+type memoryFileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func (i *memoryFileInfo) Name() string {
+	return i.name
+}
+
+func (i *memoryFileInfo) Size() int64 {
+	return i.size
+}
+
+func (i *memoryFileInfo) Mode() os.FileMode {
+	return os.FileMode(0644)
+}
+
+func (i *memoryFileInfo) ModTime() time.Time {
+	return i.modTime
+}
+
+func (i *memoryFileInfo) IsDir() bool {
+	return false
+}
+
+func (i *memoryFileInfo) Sys() interface{} {
+	return nil
+}
+
+// manualClock is Clock implementation with time
+// changed manually in tests.
+type manualClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+func newManualClock(now time.Time) *manualClock {
+	return &manualClock{now: now}
+}
+
+func (c *manualClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.now
+}
+
+func (c *manualClock) Add(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.now = c.now.Add(d)
+}
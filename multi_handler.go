@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// MultiHandler passes each log to all provided handlers. Failure of one
+// handler does not stop the others, errors from all failing handlers are
+// returned joined together (errors.Is and errors.As work on each of them).
+type MultiHandler struct {
+	handlers   []Handler
+	concurrent bool
+}
+
+func NewMultiHandler(handlers ...Handler) *MultiHandler {
+	return &MultiHandler{handlers: handlers}
+}
+
+// WithConcurrentDelivery makes handler pass logs to all handlers
+// concurrently. Handle returns after all handlers finished.
+func (h *MultiHandler) WithConcurrentDelivery() *MultiHandler {
+	h.concurrent = true
+
+	return h
+}
+
+func (h *MultiHandler) Handle(log Log) error {
+	return h.deliver(func(handler Handler) error {
+		return handler.Handle(log)
+	})
+}
+
+func (h *MultiHandler) HandleBatch(logs []Log) error {
+	return h.deliver(func(handler Handler) error {
+		return handler.HandleBatch(logs)
+	})
+}
+
+func (h *MultiHandler) children() []Handler {
+	return h.handlers
+}
+
+func (h *MultiHandler) deliver(call func(handler Handler) error) error {
+	errs := make([]error, len(h.handlers))
+
+	if h.concurrent {
+		wg := &sync.WaitGroup{}
+
+		for idx, handler := range h.handlers {
+			wg.Add(1)
+
+			go func(idx int, handler Handler) {
+				defer wg.Done()
+				errs[idx] = call(handler)
+			}(idx, handler)
+		}
+
+		wg.Wait()
+	} else {
+		for idx, handler := range h.handlers {
+			errs[idx] = call(handler)
+		}
+	}
+
+	dropped := true
+
+	for _, err := range errs {
+		if err == nil {
+			dropped = false
+		}
+	}
+
+	for idx, err := range errs {
+		if err != nil {
+			errs[idx] = fmt.Errorf(
+				"MultiHandler - handler %d returned an error: %w",
+				idx,
+				&HandlerFailure{Handler: "MultiHandler", Index: idx, Attempts: 1, Dropped: dropped, Err: err},
+			)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMultiHandler_Handle(t *testing.T) {
+	t.Parallel()
+
+	log := Log{Level: LevelInfo, Message: "test", Data: make(Data), CreatedAt: time.Now()}
+
+	mHandler1 := &mockHandler{}
+	mHandler1.On("Handle", log).Return(nil).Once()
+	mHandler2 := &mockHandler{}
+	mHandler2.On("Handle", log).Return(nil).Once()
+
+	assert.NoError(t, NewMultiHandler(mHandler1, mHandler2).Handle(log))
+
+	mHandler1.AssertExpectations(t)
+	mHandler2.AssertExpectations(t)
+}
+
+func TestMultiHandler_Handle_Failure(t *testing.T) {
+	t.Parallel()
+
+	errFirst := errors.New("disk full")
+	errThird := &testHandlerError{}
+	log := Log{Level: LevelInfo, Message: "test", Data: make(Data), CreatedAt: time.Now()}
+
+	for name, handler := range map[string]func(handlers ...Handler) *MultiHandler{
+		"sequential": NewMultiHandler,
+		"concurrent": func(handlers ...Handler) *MultiHandler {
+			return NewMultiHandler(handlers...).WithConcurrentDelivery()
+		},
+	} {
+		mHandler1 := &mockHandler{}
+		mHandler1.On("Handle", log).Return(errFirst).Once()
+		mHandler2 := &mockHandler{}
+		mHandler2.On("Handle", log).Return(nil).Once()
+		mHandler3 := &mockHandler{}
+		mHandler3.On("Handle", log).Return(errThird).Once()
+
+		err := handler(mHandler1, mHandler2, mHandler3).Handle(log)
+
+		if assert.Error(t, err, name) {
+			assert.EqualError(t, err, "MultiHandler - handler 0 returned an error: disk full\n"+
+				"MultiHandler - handler 2 returned an error: test handler error", name)
+			assert.True(t, errors.Is(err, errFirst), name)
+
+			var target *testHandlerError
+			assert.True(t, errors.As(err, &target), name)
+
+			failure := &HandlerFailure{}
+			assert.True(t, errors.As(err, &failure), name)
+			assert.False(t, failure.Dropped, name)
+		}
+
+		mHandler1.AssertExpectations(t)
+		mHandler2.AssertExpectations(t)
+		mHandler3.AssertExpectations(t)
+	}
+}
+
+func TestMultiHandler_HandleBatch(t *testing.T) {
+	t.Parallel()
+
+	logs := []Log{
+		{Level: LevelInfo, Message: "test", Data: make(Data), CreatedAt: time.Now()},
+		{Level: LevelError, Message: "test 2", Data: make(Data), CreatedAt: time.Now()},
+	}
+
+	mHandler1 := &mockHandler{}
+	mHandler1.On("HandleBatch", logs).Return(errors.New("test")).Once()
+	mHandler2 := &mockHandler{}
+	mHandler2.On("HandleBatch", logs).Return(nil).Once()
+
+	err := NewMultiHandler(mHandler1, mHandler2).WithConcurrentDelivery().HandleBatch(logs)
+
+	assert.EqualError(t, err, "MultiHandler - handler 0 returned an error: test")
+
+	mHandler1.AssertExpectations(t)
+	mHandler2.AssertExpectations(t)
+}
+
+type testHandlerError struct{}
+
+func (e *testHandlerError) Error() string {
+	return "test handler error"
+}
+
+func TestMultiHandler_Handle_AllFailed(t *testing.T) {
+	t.Parallel()
+
+	log := Log{Level: LevelInfo, Message: "test", Data: make(Data), CreatedAt: time.Now()}
+
+	mHandler1 := &mockHandler{}
+	mHandler1.On("Handle", log).Return(errors.New("first"))
+	mHandler2 := &mockHandler{}
+	mHandler2.On("Handle", log).Return(errors.New("second"))
+
+	err := NewMultiHandler(mHandler1, mHandler2).Handle(log)
+
+	failure := &HandlerFailure{}
+
+	if assert.True(t, errors.As(err, &failure)) {
+		assert.True(t, failure.Dropped)
+	}
+}
@@ -0,0 +1,222 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var ErrRateLimited = errors.New("RateLimitedHandler - log dropped because of rate limit")
+
+// RateLimitedError is returned by HandleBatch when some logs from batch were dropped
+// because of rate limit. Other logs from batch were passed to wrapped handler.
+// It matches ErrRateLimited with errors.Is.
+type RateLimitedError struct {
+	// Dropped contains logs which were not passed to wrapped handler.
+	Dropped []Log
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("%s (%d logs)", ErrRateLimited.Error(), len(e.Dropped))
+}
+
+func (e *RateLimitedError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// RateLimit defines token bucket for single level. Rate is number
+// of logs per second and Burst is max number of logs handled at once.
+type RateLimit struct {
+	Rate  float64
+	Burst uint
+}
+
+type tokenBucket struct {
+	limit      RateLimit
+	tokens     float64
+	lastRefill time.Time
+	dropped    uint64
+}
+
+// RateLimitedHandler enforces per-level token bucket in front of wrapped
+// handler. Levels without defined RateLimit are not limited. Dropped
+// logs are reported with ErrRateLimited error (*RateLimitedError for batches)
+// and when bucket refills, summary log with number of dropped logs is passed
+// to wrapped handler with the next allowed log or by Flush and Close.
+type RateLimitedHandler struct {
+	handler Handler
+	clock   Clock
+	lock    sync.Mutex
+	buckets map[Level]*tokenBucket
+}
+
+func NewRateLimitedHandler(handler Handler, limits map[Level]RateLimit) *RateLimitedHandler {
+	h := &RateLimitedHandler{handler: handler, clock: NewDefaultClock(), buckets: make(map[Level]*tokenBucket)}
+
+	for level, limit := range limits {
+		if limit.Burst == 0 {
+			limit.Burst = uint(math.Max(1, math.Ceil(limit.Rate)))
+		}
+
+		h.buckets[level] = &tokenBucket{limit: limit, tokens: float64(limit.Burst)}
+	}
+
+	return h
+}
+
+// WithClock allows to set custom implementation for
+// Clock interface used for refilling buckets.
+func (h *RateLimitedHandler) WithClock(clock Clock) *RateLimitedHandler {
+	h.clock = clock
+
+	return h
+}
+
+func (h *RateLimitedHandler) Handle(log Log) error {
+	logs, dropped := h.limit([]Log{log})
+
+	if len(dropped) > 0 {
+		return ErrRateLimited
+	}
+
+	var err error
+
+	if len(logs) == 1 {
+		err = h.handler.Handle(logs[0])
+	} else {
+		err = h.handler.HandleBatch(logs)
+	}
+
+	if err != nil {
+		return fmt.Errorf("RateLimitedHandler - wrapped handler returned an error: %w", err)
+	}
+
+	return nil
+}
+
+func (h *RateLimitedHandler) HandleBatch(logs []Log) error {
+	allowed, dropped := h.limit(logs)
+
+	if len(allowed) > 0 {
+		err := h.handler.HandleBatch(allowed)
+
+		if err != nil {
+			return fmt.Errorf("RateLimitedHandler - wrapped handler returned an error: %w", err)
+		}
+	}
+
+	if len(dropped) > 0 {
+		return &RateLimitedError{Dropped: dropped}
+	}
+
+	return nil
+}
+
+// Flush passes summaries of all logs dropped so far to wrapped
+// handler, so they are not lost when logs stop coming.
+func (h *RateLimitedHandler) Flush() error {
+	h.lock.Lock()
+
+	var summaries []Log
+	now := h.clock.Now()
+
+	for level, bucket := range h.buckets {
+		if bucket.dropped > 0 {
+			summaries = append(summaries, bucket.summary(level, now))
+		}
+	}
+
+	h.lock.Unlock()
+
+	if len(summaries) == 0 {
+		return nil
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Level < summaries[j].Level
+	})
+
+	err := h.handler.HandleBatch(summaries)
+
+	if err != nil {
+		return fmt.Errorf("RateLimitedHandler - wrapped handler returned an error: %w", err)
+	}
+
+	return nil
+}
+
+// Close passes summaries of dropped logs to wrapped
+// handler. Wrapped handler is not closed.
+func (h *RateLimitedHandler) Close() error {
+	return h.Flush()
+}
+
+// limit returns allowed logs (preceded by summaries of
+// previously dropped logs) and dropped logs.
+func (h *RateLimitedHandler) limit(logs []Log) ([]Log, []Log) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	var allowed, dropped []Log
+	now := h.clock.Now()
+
+	for _, log := range logs {
+		bucket, ok := h.buckets[log.Level]
+
+		if !ok {
+			allowed = append(allowed, log)
+
+			continue
+		}
+
+		bucket.refill(now)
+
+		if bucket.tokens < 1 {
+			bucket.dropped++
+			dropped = append(dropped, log)
+
+			continue
+		}
+
+		bucket.tokens--
+
+		if bucket.dropped > 0 {
+			allowed = append(allowed, bucket.summary(log.Level, now))
+		}
+
+		allowed = append(allowed, log)
+	}
+
+	return allowed, dropped
+}
+
+// summary returns log with number of dropped logs and resets it.
+func (b *tokenBucket) summary(level Level, now time.Time) Log {
+	log := Log{
+		Level:     level,
+		Message:   fmt.Sprintf("RateLimitedHandler - %d logs dropped", b.dropped),
+		Data:      Data{"dropped": strconv.FormatUint(b.dropped, 10)},
+		CreatedAt: now,
+	}
+	b.dropped = 0
+
+	return log
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if !b.lastRefill.IsZero() && now.After(b.lastRefill) {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+now.Sub(b.lastRefill).Seconds()*b.limit.Rate)
+	}
+
+	if b.lastRefill.IsZero() || now.After(b.lastRefill) {
+		b.lastRefill = now
+	}
+}
+
+func (h *RateLimitedHandler) children() []Handler {
+	return []Handler{h.handler}
+}
@@ -0,0 +1,158 @@
+package logger
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRateLimitedHandler_Handle(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock(time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC))
+	memory := NewInMemoryHandler(0)
+	handler := NewRateLimitedHandler(memory, map[Level]RateLimit{
+		LevelDebug: {Rate: 2, Burst: 2},
+	}).WithClock(clock)
+
+	debugLog := Log{Level: LevelDebug, Message: "debug"}
+	criticalLog := Log{Level: LevelCritical, Message: "critical"}
+
+	assert.NoError(t, handler.Handle(debugLog))
+	assert.NoError(t, handler.Handle(debugLog))
+	assert.Equal(t, ErrRateLimited, handler.Handle(debugLog))
+	assert.Equal(t, ErrRateLimited, handler.Handle(debugLog))
+
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, handler.Handle(criticalLog))
+	}
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, criticalLog, memory.Pop())
+	}
+
+	assert.Equal(t, debugLog, memory.Pop())
+	assert.Equal(t, debugLog, memory.Pop())
+	assert.True(t, memory.IsEmpty())
+
+	clock.Add(500 * time.Millisecond)
+	assert.NoError(t, handler.Handle(debugLog))
+	assert.Equal(t, ErrRateLimited, handler.Handle(debugLog))
+
+	assert.Equal(t, debugLog, memory.Pop())
+	assert.Equal(t, Log{
+		Level:     LevelDebug,
+		Message:   "RateLimitedHandler - 2 logs dropped",
+		Data:      Data{"dropped": "2"},
+		CreatedAt: clock.Now(),
+	}, memory.Pop())
+	assert.True(t, memory.IsEmpty())
+}
+
+func TestRateLimitedHandler_HandleBatch(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock(time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC))
+	memory := NewInMemoryHandler(0)
+	handler := NewRateLimitedHandler(memory, map[Level]RateLimit{
+		LevelInfo: {Rate: 1},
+	}).WithClock(clock)
+
+	err := handler.HandleBatch([]Log{
+		{Level: LevelInfo, Message: "info 1"},
+		{Level: LevelError, Message: "error"},
+		{Level: LevelInfo, Message: "info 2"},
+	})
+
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, ErrRateLimited))
+		assert.EqualError(t, err, "RateLimitedHandler - log dropped because of rate limit (1 logs)")
+
+		rlErr := &RateLimitedError{}
+
+		if assert.True(t, errors.As(err, &rlErr)) {
+			assert.Equal(t, []Log{{Level: LevelInfo, Message: "info 2"}}, rlErr.Dropped)
+		}
+	}
+
+	assert.Equal(t, "error", memory.Pop().Message)
+	assert.Equal(t, "info 1", memory.Pop().Message)
+	assert.True(t, memory.IsEmpty())
+}
+
+func TestRateLimitedHandler_Flush(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock(time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC))
+	memory := NewInMemoryHandler(0)
+	handler := NewRateLimitedHandler(memory, map[Level]RateLimit{
+		LevelInfo:    {Rate: 1},
+		LevelWarning: {Rate: 1},
+	}).WithClock(clock)
+
+	assert.NoError(t, handler.Handle(Log{Level: LevelWarning, Message: "warning"}))
+	assert.NoError(t, handler.Handle(Log{Level: LevelInfo, Message: "info"}))
+	assert.True(t, errors.Is(handler.Handle(Log{Level: LevelWarning, Message: "warning"}), ErrRateLimited))
+	assert.True(t, errors.Is(handler.Handle(Log{Level: LevelInfo, Message: "info"}), ErrRateLimited))
+	assert.True(t, errors.Is(handler.Handle(Log{Level: LevelInfo, Message: "info"}), ErrRateLimited))
+
+	assert.NoError(t, handler.Close())
+
+	// summaries are passed last, sorted by level
+	assert.Equal(t, Log{
+		Level:     LevelWarning,
+		Message:   "RateLimitedHandler - 1 logs dropped",
+		Data:      Data{"dropped": "1"},
+		CreatedAt: clock.Now(),
+	}, memory.Pop())
+	assert.Equal(t, Log{
+		Level:     LevelInfo,
+		Message:   "RateLimitedHandler - 2 logs dropped",
+		Data:      Data{"dropped": "2"},
+		CreatedAt: clock.Now(),
+	}, memory.Pop())
+	assert.Equal(t, "info", memory.Pop().Message)
+	assert.Equal(t, "warning", memory.Pop().Message)
+	assert.True(t, memory.IsEmpty())
+
+	assert.NoError(t, handler.Flush())
+	assert.True(t, memory.IsEmpty())
+}
+
+func TestRateLimitedHandler_WithFailureHandler(t *testing.T) {
+	t.Parallel()
+
+	var failures []error
+
+	handler := NewRateLimitedHandler(NewInMemoryHandler(0), map[Level]RateLimit{LevelInfo: {Rate: 1}}).
+		WithClock(newManualClock(time.Now()))
+
+	logger := New(handler).WithFailureHandler(func(log Log, err error) {
+		failures = append(failures, err)
+	})
+
+	logger.Info("test")
+	logger.Info("test")
+	logger.Error("test")
+
+	if assert.Len(t, failures, 1) {
+		assert.True(t, errors.Is(failures[0], ErrRateLimited))
+	}
+}
+
+func TestRateLimitedHandler_Handle_Failure(t *testing.T) {
+	t.Parallel()
+
+	log := Log{Level: LevelInfo, Message: "test", Data: make(Data), CreatedAt: time.Now()}
+
+	mHandler := &mockHandler{}
+	mHandler.On("Handle", log).Return(errors.New("test"))
+
+	err := NewRateLimitedHandler(mHandler, nil).Handle(log)
+
+	if assert.Error(t, err) {
+		assert.EqualError(t, err, "RateLimitedHandler - wrapped handler returned an error: test")
+		mHandler.AssertExpectations(t)
+	}
+}
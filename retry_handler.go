@@ -0,0 +1,190 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// permanentError marks error which should not be retried by RetryHandler.
+type permanentError struct {
+	err error
+}
+
+// Permanent marks error returned by handler as permanent,
+// so RetryHandler does not retry it.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &permanentError{err: err}
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// RetryHandler retries failed Handle/HandleBatch calls of wrapped handler with
+// exponential backoff and jitter. When all attempts fail (or error is permanent),
+// logs are passed to dead-letter handler (if set).
+type RetryHandler struct {
+	handler           Handler
+	deadLetterHandler Handler
+	failureHandler    FailureHandleFunc
+	maxAttempts       int
+	initialBackoff    time.Duration
+	maxBackoff        time.Duration
+	jitter            float64
+	sleep             func(d time.Duration)
+	isRetryable       func(err error) bool
+}
+
+// NewRetryHandler creates RetryHandler making up to "maxAttempts" attempts.
+// Delay starts at "initialBackoff" and doubles after each attempt up to "maxBackoff".
+func NewRetryHandler(handler Handler, maxAttempts uint, initialBackoff time.Duration, maxBackoff time.Duration) *RetryHandler {
+	if maxAttempts == 0 {
+		maxAttempts = 1
+	}
+
+	return &RetryHandler{
+		handler:        handler,
+		maxAttempts:    int(maxAttempts),
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
+		jitter:         0.5,
+		sleep:          time.Sleep,
+		isRetryable: func(err error) bool {
+			var pErr *permanentError
+
+			return !errors.As(err, &pErr)
+		},
+	}
+}
+
+// WithDeadLetterHandler sets handler which receives logs that could not
+// be handled by wrapped handler (e.g. FileHandler writing to local file).
+func (h *RetryHandler) WithDeadLetterHandler(handler Handler) *RetryHandler {
+	h.deadLetterHandler = handler
+
+	return h
+}
+
+// WithFailureHandler sets function called when logs were
+// passed to dead-letter handler (error is *HandlerFailure).
+func (h *RetryHandler) WithFailureHandler(handleFunc FailureHandleFunc) *RetryHandler {
+	h.failureHandler = handleFunc
+
+	return h
+}
+
+// WithJitter changes part of delay (0-1, 0.5 by default) which is randomized.
+// Zero disables jitter.
+func (h *RetryHandler) WithJitter(jitter float64) *RetryHandler {
+	h.jitter = jitter
+
+	return h
+}
+
+// WithSleeper allows to replace time.Sleep used to wait between attempts.
+func (h *RetryHandler) WithSleeper(sleep func(d time.Duration)) *RetryHandler {
+	h.sleep = sleep
+
+	return h
+}
+
+// WithRetryClassifier sets function which decides if error can be retried.
+// By default all errors except those marked with Permanent are retried.
+func (h *RetryHandler) WithRetryClassifier(isRetryable func(err error) bool) *RetryHandler {
+	h.isRetryable = isRetryable
+
+	return h
+}
+
+func (h *RetryHandler) Handle(log Log) error {
+	return h.handleWithRetry([]Log{log}, func(handler Handler) error {
+		return handler.Handle(log)
+	})
+}
+
+func (h *RetryHandler) HandleBatch(logs []Log) error {
+	return h.handleWithRetry(logs, func(handler Handler) error {
+		return handler.HandleBatch(logs)
+	})
+}
+
+func (h *RetryHandler) handleWithRetry(logs []Log, call func(handler Handler) error) error {
+	var err error
+	attempts := 0
+
+	for attempts < h.maxAttempts {
+		if attempts > 0 {
+			h.sleep(h.backoff(attempts))
+		}
+
+		attempts++
+		err = call(h.handler)
+
+		if err == nil {
+			return nil
+		}
+
+		if !h.isRetryable(err) {
+			break
+		}
+	}
+
+	failure := &HandlerFailure{Handler: "RetryHandler", Index: -1, Attempts: attempts, Dropped: true, Err: err}
+
+	if h.deadLetterHandler != nil {
+		dErr := h.deadLetterHandler.HandleBatch(logs)
+
+		if dErr == nil {
+			failure.Dropped = false
+
+			if h.failureHandler != nil {
+				for _, log := range logs {
+					h.failureHandler(log, failure)
+				}
+			}
+
+			return nil
+		}
+
+		failure.Err = errors.Join(err, fmt.Errorf("dead-letter handler returned an error: %w", dErr))
+	}
+
+	return fmt.Errorf("RetryHandler - wrapped handler returned an error after %d attempts: %w", attempts, failure)
+}
+
+func (h *RetryHandler) children() []Handler {
+	if h.deadLetterHandler == nil {
+		return []Handler{h.handler}
+	}
+
+	return []Handler{h.handler, h.deadLetterHandler}
+}
+
+// backoff returns delay before next attempt after "attempts" failed ones.
+func (h *RetryHandler) backoff(attempts int) time.Duration {
+	delay := h.initialBackoff
+
+	for i := 1; i < attempts && delay < h.maxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > h.maxBackoff {
+		delay = h.maxBackoff
+	}
+
+	if h.jitter > 0 {
+		delay -= time.Duration(float64(delay) * h.jitter * rand.Float64())
+	}
+
+	return delay
+}
@@ -0,0 +1,142 @@
+package logger
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestRetryHandler_Handle(t *testing.T) {
+	t.Parallel()
+
+	log := Log{Level: LevelInfo, Message: "test", Data: make(Data), CreatedAt: time.Now()}
+
+	mHandler := &mockHandler{}
+	mHandler.On("Handle", log).Return(errors.New("timeout")).Times(3)
+	mHandler.On("Handle", log).Return(nil).Once()
+
+	var delays []time.Duration
+	handler := NewRetryHandler(mHandler, 5, 100*time.Millisecond, 250*time.Millisecond).
+		WithJitter(0).
+		WithSleeper(func(d time.Duration) {
+			delays = append(delays, d)
+		})
+
+	assert.NoError(t, handler.Handle(log))
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 250 * time.Millisecond}, delays)
+
+	mHandler.AssertExpectations(t)
+}
+
+func TestRetryHandler_HandleBatch_DeadLetter(t *testing.T) {
+	t.Parallel()
+
+	logs := []Log{
+		{Level: LevelInfo, Message: "test", Data: make(Data), CreatedAt: time.Now()},
+		{Level: LevelError, Message: "test 2", Data: make(Data), CreatedAt: time.Now()},
+	}
+
+	mHandler := &mockHandler{}
+	mHandler.On("HandleBatch", logs).Return(errors.New("timeout")).Times(3)
+
+	var failures []*HandlerFailure
+	deadLetter := NewInMemoryHandler(0)
+	handler := NewRetryHandler(mHandler, 3, time.Millisecond, time.Second).
+		WithSleeper(func(time.Duration) {}).
+		WithDeadLetterHandler(deadLetter).
+		WithFailureHandler(func(log Log, err error) {
+			failures = append(failures, err.(*HandlerFailure))
+		})
+
+	assert.NoError(t, handler.HandleBatch(logs))
+	assert.Equal(t, "test 2", deadLetter.Pop().Message)
+	assert.Equal(t, "test", deadLetter.Pop().Message)
+
+	if assert.Len(t, failures, 2) {
+		assert.Equal(t, 3, failures[0].Attempts)
+		assert.False(t, failures[0].Dropped)
+		assert.EqualError(t, failures[0], "timeout")
+	}
+
+	mHandler.AssertExpectations(t)
+}
+
+func TestRetryHandler_Handle_Permanent(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("invalid log")
+
+	mHandler := &mockHandler{}
+	mHandler.On("Handle", mock.IsType(Log{})).Return(Permanent(err)).Once()
+
+	hErr := NewRetryHandler(mHandler, 5, time.Millisecond, time.Second).
+		WithSleeper(func(time.Duration) {
+			t.Fatal("permanent error should not be retried")
+		}).
+		Handle(Log{Message: "test"})
+
+	if assert.Error(t, hErr) {
+		assert.EqualError(t, hErr, "RetryHandler - wrapped handler returned an error after 1 attempts: invalid log")
+		assert.True(t, errors.Is(hErr, err))
+
+		failure := &HandlerFailure{}
+		assert.True(t, errors.As(hErr, &failure))
+		assert.Equal(t, 1, failure.Attempts)
+		assert.True(t, failure.Dropped)
+	}
+
+	mHandler.AssertExpectations(t)
+}
+
+func TestRetryHandler_WithRetryClassifier(t *testing.T) {
+	t.Parallel()
+
+	mHandler := &mockHandler{}
+	mHandler.On("Handle", mock.IsType(Log{})).Return(errors.New("test")).Twice()
+
+	deadLetter := &mockHandler{}
+	deadLetter.On("HandleBatch", mock.Anything).Return(errors.New("disk full")).Once()
+
+	attempts := 0
+	hErr := NewRetryHandler(mHandler, 5, time.Millisecond, time.Second).
+		WithSleeper(func(time.Duration) {}).
+		WithDeadLetterHandler(deadLetter).
+		WithRetryClassifier(func(err error) bool {
+			attempts++
+
+			return attempts < 2
+		}).
+		Handle(Log{Message: "test"})
+
+	assert.EqualError(t, hErr, "RetryHandler - wrapped handler returned an error after 2 attempts: test\n"+
+		"dead-letter handler returned an error: disk full")
+
+	mHandler.AssertExpectations(t)
+	deadLetter.AssertExpectations(t)
+}
+
+func TestRetryHandler_backoff(t *testing.T) {
+	t.Parallel()
+
+	handler := NewRetryHandler(nil, 10, 100*time.Millisecond, time.Second)
+
+	for attempts := 1; attempts < 10; attempts++ {
+		delay := handler.backoff(attempts)
+		expected := time.Duration(100<<(attempts-1)) * time.Millisecond
+
+		if expected > time.Second {
+			expected = time.Second
+		}
+
+		assert.True(t, delay > expected/2 && delay <= expected, attempts)
+	}
+}
+
+func TestPermanent(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, Permanent(nil))
+	assert.EqualError(t, Permanent(errors.New("test")), "test")
+}
@@ -0,0 +1,546 @@
+package logger
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotationInterval defines time boundary after which log file is rotated.
+type RotationInterval int
+
+const (
+	RotateNever = RotationInterval(iota)
+	RotateHourly
+	RotateDaily
+)
+
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotatingFileHandler writes all logs to provided log file and
+// rotates it when file exceeds max size or when rotation interval
+// boundary is crossed. Rotated files are renamed to
+// "<path>.<timestamp>" (with additional ".<sequence>" suffix
+// when backup with the same timestamp already exists) and
+// optionally compressed in background.
+type RotatingFileHandler struct {
+	fileLock       sync.Mutex
+	file           File
+	filesystem     Filesystem
+	clock          Clock
+	path           string
+	formatter      Formatter
+	maxSize        int64
+	interval       RotationInterval
+	maxBackups     uint
+	maxAge         time.Duration
+	size           int64
+	openedAt       time.Time
+	compressor     Compressor
+	compressions   sync.WaitGroup
+	failureHandler FailureHandleFunc
+}
+
+type backupFile struct {
+	names     []string
+	createdAt time.Time
+	sequence  int
+}
+
+// NewRotatingFileHandler creates RotatingFileHandler with main log file
+// expected to be at "path" location. File is rotated when its size exceeds
+// "maxSize" bytes (zero disables size rotation) or when "interval" boundary is crossed.
+func NewRotatingFileHandler(path string, maxSize int64, interval RotationInterval) *RotatingFileHandler {
+	return &RotatingFileHandler{
+		path:       path,
+		maxSize:    maxSize,
+		interval:   interval,
+		filesystem: NewDefaultFilesystem(),
+		clock:      NewDefaultClock(),
+	}
+}
+
+// WithFilesystem allows to replace default Filesystem
+// implementation with custom made.
+func (f *RotatingFileHandler) WithFilesystem(filesystem Filesystem) *RotatingFileHandler {
+	f.filesystem = filesystem
+
+	return f
+}
+
+// WithClock allows to set custom implementation for Clock
+// used for rotation intervals and backup names.
+func (f *RotatingFileHandler) WithClock(clock Clock) *RotatingFileHandler {
+	f.clock = clock
+
+	return f
+}
+
+// WithMaxBackups sets max number of kept backups (zero - no limit).
+func (f *RotatingFileHandler) WithMaxBackups(maxBackups uint) *RotatingFileHandler {
+	f.maxBackups = maxBackups
+
+	return f
+}
+
+// WithMaxAge sets max age of kept backups (zero - no limit).
+func (f *RotatingFileHandler) WithMaxAge(maxAge time.Duration) *RotatingFileHandler {
+	f.maxAge = maxAge
+
+	return f
+}
+
+// WithCompression enables compression of rotated files with provided Compressor.
+// Uncompressed file is removed only after compressed one is fully written and synced.
+func (f *RotatingFileHandler) WithCompression(compressor Compressor) *RotatingFileHandler {
+	f.compressor = compressor
+
+	return f
+}
+
+// WithFailureHandler sets function called when background compression fails.
+// Provided Log describes failure and contains path of the rotated file.
+func (f *RotatingFileHandler) WithFailureHandler(handleFunc FailureHandleFunc) *RotatingFileHandler {
+	f.failureHandler = handleFunc
+
+	return f
+}
+
+func (f *RotatingFileHandler) UseFormatter(formatter Formatter) *RotatingFileHandler {
+	f.formatter = formatter
+
+	return f
+}
+
+func (f *RotatingFileHandler) Handle(log Log) error {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+
+	return f.write([]string{f.formatMessage(log)})
+}
+
+func (f *RotatingFileHandler) HandleBatch(logs []Log) error {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+
+	messages := make([]string, 0, len(logs))
+
+	for _, l := range logs {
+		messages = append(messages, f.formatMessage(l))
+	}
+
+	return f.write(messages)
+}
+
+// Rotate closes current log file and renames it to backup
+// regardless of size and interval.
+func (f *RotatingFileHandler) Rotate() error {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+
+	return f.rotate()
+}
+
+// Wait blocks until all background compressions are finished.
+func (f *RotatingFileHandler) Wait() {
+	f.compressions.Wait()
+}
+
+// Reopen closes current log file and opens file at handler path again.
+// It should be used when file was moved by external tool (e.g. logrotate).
+func (f *RotatingFileHandler) Reopen() error {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+
+	if f.file != nil {
+		cErr := f.file.Close()
+		f.file = nil
+
+		if cErr != nil {
+			return fmt.Errorf("RotatingFileHandler - unable to close log file: %w", cErr)
+		}
+	}
+
+	return f.openFile()
+}
+
+// Close closes log file and waits for background compressions.
+func (f *RotatingFileHandler) Close() error {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+	defer f.compressions.Wait()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+
+	return err
+}
+
+func (f *RotatingFileHandler) formatMessage(log Log) string {
+	if f.formatter != nil {
+		return fmt.Sprintf("%s\n", f.formatter.Format(log).FormattedMessage)
+	}
+
+	return fmt.Sprintf("%s\n", log.Message)
+}
+
+func (f *RotatingFileHandler) write(messages []string) error {
+	ofErr := f.openFile()
+
+	if ofErr != nil {
+		return ofErr
+	}
+
+	writer := bufio.NewWriter(f.file)
+
+	for _, message := range messages {
+		if f.shouldRotate(int64(len(message))) {
+			fwErr := writer.Flush()
+
+			if fwErr != nil {
+				return fmt.Errorf("RotatingFileHandler - unable to write log to file: %w", fwErr)
+			}
+
+			rErr := f.rotate()
+
+			if rErr != nil {
+				return rErr
+			}
+
+			ofErr = f.openFile()
+
+			if ofErr != nil {
+				return ofErr
+			}
+
+			writer = bufio.NewWriter(f.file)
+		}
+
+		n, bwErr := writer.WriteString(message)
+		f.size += int64(n)
+
+		if bwErr != nil {
+			return fmt.Errorf("RotatingFileHandler - unable to write log to buffer: %w", bwErr)
+		}
+	}
+
+	fwErr := writer.Flush()
+
+	if fwErr != nil {
+		return fmt.Errorf("RotatingFileHandler - unable to write log to file: %w", fwErr)
+	}
+
+	return nil
+}
+
+func (f *RotatingFileHandler) shouldRotate(messageSize int64) bool {
+	if f.maxSize > 0 && f.size > 0 && f.size+messageSize > f.maxSize {
+		return true
+	}
+
+	return f.interval != RotateNever && !f.periodStart(f.clock.Now()).Equal(f.periodStart(f.openedAt))
+}
+
+func (f *RotatingFileHandler) periodStart(tm time.Time) time.Time {
+	switch f.interval {
+	case RotateHourly:
+		return time.Date(tm.Year(), tm.Month(), tm.Day(), tm.Hour(), 0, 0, 0, tm.Location())
+	case RotateDaily:
+		return time.Date(tm.Year(), tm.Month(), tm.Day(), 0, 0, 0, 0, tm.Location())
+	default:
+		return time.Time{}
+	}
+}
+
+func (f *RotatingFileHandler) openFile() error {
+	if f.file != nil {
+		return nil
+	}
+
+	f.size = 0
+	f.openedAt = f.clock.Now()
+	info, sErr := f.filesystem.Stat(f.path)
+
+	if sErr == nil {
+		f.size = info.Size()
+		f.openedAt = info.ModTime()
+	} else if !errors.Is(sErr, os.ErrNotExist) {
+		return fmt.Errorf("RotatingFileHandler - unable to read log file info: %w", sErr)
+	}
+
+	var lfErr error
+	f.file, lfErr = f.filesystem.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, os.FileMode(0644))
+
+	if lfErr != nil {
+		f.file = nil
+
+		return fmt.Errorf("RotatingFileHandler - unable to open log file: %w", lfErr)
+	}
+
+	return nil
+}
+
+func (f *RotatingFileHandler) rotate() error {
+	if f.file != nil {
+		cErr := f.file.Close()
+		f.file = nil
+
+		if cErr != nil {
+			return fmt.Errorf("RotatingFileHandler - unable to close log file: %w", cErr)
+		}
+	}
+
+	backupPath, bpErr := f.nextBackupPath()
+
+	if bpErr != nil {
+		return bpErr
+	}
+
+	rErr := f.filesystem.Rename(f.path, backupPath)
+
+	if rErr != nil {
+		if errors.Is(rErr, os.ErrNotExist) {
+			return f.removeOldBackups()
+		}
+
+		return fmt.Errorf("RotatingFileHandler - unable to rename log file: %w", rErr)
+	}
+
+	if f.compressor != nil {
+		f.compressions.Add(1)
+
+		go func() {
+			defer f.compressions.Done()
+
+			cErr := f.compress(backupPath)
+
+			if cErr != nil && f.failureHandler != nil {
+				f.failureHandler(Log{
+					Level:     LevelError,
+					Message:   "RotatingFileHandler - unable to compress rotated log file",
+					Data:      Data{"path": backupPath},
+					CreatedAt: f.clock.Now(),
+				}, cErr)
+			}
+		}()
+	}
+
+	return f.removeOldBackups()
+}
+
+func (f *RotatingFileHandler) compress(path string) error {
+	src, oErr := f.filesystem.Open(path)
+
+	if oErr != nil {
+		return fmt.Errorf("RotatingFileHandler - unable to open rotated log file: %w", oErr)
+	}
+
+	defer src.Close()
+
+	compressedPath := path + f.compressor.Extension()
+	tmpPath := compressedPath + ".tmp"
+	dst, ofErr := f.filesystem.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(0644))
+
+	if ofErr != nil {
+		return fmt.Errorf("RotatingFileHandler - unable to create compressed file: %w", ofErr)
+	}
+
+	cErr := f.compressor.Compress(dst, src)
+
+	if cErr == nil {
+		cErr = dst.Sync()
+	}
+
+	clErr := dst.Close()
+
+	if cErr == nil {
+		cErr = clErr
+	}
+
+	if cErr != nil {
+		_ = f.filesystem.Remove(tmpPath)
+
+		return fmt.Errorf("RotatingFileHandler - unable to write compressed file: %w", cErr)
+	}
+
+	rErr := f.filesystem.Rename(tmpPath, compressedPath)
+
+	if rErr != nil {
+		return fmt.Errorf("RotatingFileHandler - unable to rename compressed file: %w", rErr)
+	}
+
+	rmErr := f.filesystem.Remove(path)
+
+	if rmErr != nil {
+		return fmt.Errorf("RotatingFileHandler - unable to remove rotated log file: %w", rmErr)
+	}
+
+	return nil
+}
+
+func (f *RotatingFileHandler) nextBackupPath() (string, error) {
+	base := fmt.Sprintf("%s.%s", f.path, f.clock.Now().UTC().Format(backupTimeFormat))
+	backupPath := base
+
+	for sequence := 1; ; sequence++ {
+		exists, eErr := f.backupExists(backupPath)
+
+		if eErr != nil {
+			return "", eErr
+		}
+
+		if !exists {
+			return backupPath, nil
+		}
+
+		backupPath = fmt.Sprintf("%s.%d", base, sequence)
+	}
+}
+
+func (f *RotatingFileHandler) backupExists(path string) (bool, error) {
+	paths := []string{path}
+
+	if f.compressor != nil {
+		paths = append(paths, path+f.compressor.Extension())
+	}
+
+	for _, p := range paths {
+		_, sErr := f.filesystem.Stat(p)
+
+		if sErr == nil {
+			return true, nil
+		}
+
+		if !errors.Is(sErr, os.ErrNotExist) {
+			return false, fmt.Errorf("RotatingFileHandler - unable to read backup file info: %w", sErr)
+		}
+	}
+
+	return false, nil
+}
+
+func (f *RotatingFileHandler) removeOldBackups() error {
+	if f.maxBackups == 0 && f.maxAge == 0 {
+		return nil
+	}
+
+	backups, lbErr := f.listBackups()
+
+	if lbErr != nil {
+		return lbErr
+	}
+
+	minTime := f.clock.Now().Add(-f.maxAge)
+
+	for idx, backup := range backups {
+		if (f.maxBackups > 0 && uint(idx) >= f.maxBackups) || (f.maxAge > 0 && backup.createdAt.Before(minTime)) {
+			for _, name := range backup.names {
+				rErr := f.filesystem.Remove(filepath.Join(filepath.Dir(f.path), name))
+
+				if rErr != nil && !errors.Is(rErr, os.ErrNotExist) {
+					return fmt.Errorf("RotatingFileHandler - unable to remove old backup: %w", rErr)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// listBackups returns all backups of log file sorted from the newest one.
+// Uncompressed and compressed versions of the same backup are grouped together.
+func (f *RotatingFileHandler) listBackups() ([]backupFile, error) {
+	files, rdErr := f.filesystem.ReadDir(filepath.Dir(f.path))
+
+	if rdErr != nil {
+		return nil, fmt.Errorf("RotatingFileHandler - unable to list backups: %w", rdErr)
+	}
+
+	var backups []backupFile
+	grouped := make(map[string]int)
+	prefix := filepath.Base(f.path) + "."
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasPrefix(file.Name(), prefix) {
+			continue
+		}
+
+		backup, ok := parseBackupName(file.Name(), strings.TrimPrefix(file.Name(), prefix))
+
+		if !ok {
+			continue
+		}
+
+		key := fmt.Sprintf("%d.%d", backup.createdAt.UnixNano(), backup.sequence)
+
+		if idx, exists := grouped[key]; exists {
+			backups[idx].names = append(backups[idx].names, file.Name())
+
+			continue
+		}
+
+		grouped[key] = len(backups)
+		backups = append(backups, backup)
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		if backups[i].createdAt.Equal(backups[j].createdAt) {
+			return backups[i].sequence > backups[j].sequence
+		}
+
+		return backups[i].createdAt.After(backups[j].createdAt)
+	})
+
+	return backups, nil
+}
+
+// parseBackupName parses backup name suffix in "<timestamp>[.<sequence>][.<extension>]"
+// format. Any other file (e.g. temporary compressed file) is not treated as backup.
+func parseBackupName(name string, suffix string) (backupFile, bool) {
+	if len(suffix) < len(backupTimeFormat) {
+		return backupFile{}, false
+	}
+
+	createdAt, pErr := time.Parse(backupTimeFormat, suffix[:len(backupTimeFormat)])
+
+	if pErr != nil {
+		return backupFile{}, false
+	}
+
+	backup := backupFile{names: []string{name}, createdAt: createdAt}
+	rest := suffix[len(backupTimeFormat):]
+
+	if rest == "" {
+		return backup, true
+	}
+
+	parts := strings.Split(rest[1:], ".")
+
+	if rest[0] != '.' || len(parts) > 2 {
+		return backupFile{}, false
+	}
+
+	if sequence, aErr := strconv.Atoi(parts[0]); aErr == nil {
+		backup.sequence = sequence
+		parts = parts[1:]
+	}
+
+	for _, part := range parts {
+		if _, aErr := strconv.Atoi(part); aErr == nil || part == "" {
+			return backupFile{}, false
+		}
+	}
+
+	return backup, len(parts) < 2
+}
@@ -0,0 +1,310 @@
+package logger
+
+import (
+	"compress/gzip"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFileHandler_Handle_SizeRotation(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock(time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC))
+	fs := newMemoryFilesystem(clock)
+
+	fh := NewRotatingFileHandler("/var/log/app.log", 20, RotateNever).WithFilesystem(fs).WithClock(clock)
+
+	assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "first message"}))
+	assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "second message"}))
+	assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "third message"}))
+
+	assert.Equal(t, []string{
+		"/var/log/app.log",
+		"/var/log/app.log.2020-08-25T19-06-36.000",
+		"/var/log/app.log.2020-08-25T19-06-36.000.1",
+	}, fs.names())
+
+	content, _ := fs.content("/var/log/app.log")
+	assert.Equal(t, "third message\n", content)
+
+	content, _ = fs.content("/var/log/app.log.2020-08-25T19-06-36.000")
+	assert.Equal(t, "first message\n", content)
+
+	content, _ = fs.content("/var/log/app.log.2020-08-25T19-06-36.000.1")
+	assert.Equal(t, "second message\n", content)
+}
+
+func TestRotatingFileHandler_HandleBatch_SizeRotation(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock(time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC))
+	fs := newMemoryFilesystem(clock)
+
+	fh := NewRotatingFileHandler("/var/log/app.log", 30, RotateNever).WithFilesystem(fs).WithClock(clock)
+
+	assert.NoError(t, fh.HandleBatch([]Log{
+		{Level: LevelInfo, Message: "first message"},
+		{Level: LevelInfo, Message: "second message"},
+		{Level: LevelInfo, Message: "third message"},
+	}))
+
+	content, _ := fs.content("/var/log/app.log")
+	assert.Equal(t, "third message\n", content)
+
+	content, _ = fs.content("/var/log/app.log.2020-08-25T19-06-36.000")
+	assert.Equal(t, "first message\nsecond message\n", content)
+}
+
+func TestRotatingFileHandler_Handle_IntervalRotation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("hourly", func(t *testing.T) {
+		clock := newManualClock(time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC))
+		fs := newMemoryFilesystem(clock)
+
+		fh := NewRotatingFileHandler("/var/log/app.log", 0, RotateHourly).WithFilesystem(fs).WithClock(clock)
+
+		assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "first message"}))
+		clock.Add(50 * time.Minute)
+		assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "second message"}))
+
+		assert.Equal(t, []string{"/var/log/app.log"}, fs.names())
+
+		clock.Add(5 * time.Minute)
+		assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "third message"}))
+
+		assert.Equal(t, []string{"/var/log/app.log", "/var/log/app.log.2020-08-25T20-01-36.000"}, fs.names())
+
+		content, _ := fs.content("/var/log/app.log.2020-08-25T20-01-36.000")
+		assert.Equal(t, "first message\nsecond message\n", content)
+	})
+
+	t.Run("daily", func(t *testing.T) {
+		clock := newManualClock(time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC))
+		fs := newMemoryFilesystem(clock)
+
+		fh := NewRotatingFileHandler("/var/log/app.log", 0, RotateDaily).WithFilesystem(fs).WithClock(clock)
+
+		assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "first message"}))
+		clock.Add(4 * time.Hour)
+		assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "second message"}))
+
+		assert.Equal(t, []string{"/var/log/app.log"}, fs.names())
+
+		clock.Add(time.Hour)
+		assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "third message"}))
+
+		assert.Equal(t, []string{"/var/log/app.log", "/var/log/app.log.2020-08-26T00-06-36.000"}, fs.names())
+	})
+}
+
+func TestRotatingFileHandler_Rotate_MaxBackups(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock(time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC))
+	fs := newMemoryFilesystem(clock)
+
+	fh := NewRotatingFileHandler("/var/log/app.log", 0, RotateNever).WithFilesystem(fs).WithClock(clock).WithMaxBackups(2)
+
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "message"}))
+		assert.NoError(t, fh.Rotate())
+		clock.Add(time.Second)
+	}
+
+	assert.Equal(t, []string{
+		"/var/log/app.log.2020-08-25T19-06-38.000",
+		"/var/log/app.log.2020-08-25T19-06-39.000",
+	}, fs.names())
+}
+
+func TestRotatingFileHandler_Rotate_MaxAge(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock(time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC))
+	fs := newMemoryFilesystem(clock)
+
+	fh := NewRotatingFileHandler("/var/log/app.log", 0, RotateNever).WithFilesystem(fs).WithClock(clock).WithMaxAge(48 * time.Hour)
+
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "message"}))
+		assert.NoError(t, fh.Rotate())
+		clock.Add(24 * time.Hour)
+	}
+
+	assert.Equal(t, []string{
+		"/var/log/app.log.2020-08-26T19-06-36.000",
+		"/var/log/app.log.2020-08-27T19-06-36.000",
+		"/var/log/app.log.2020-08-28T19-06-36.000",
+	}, fs.names())
+}
+
+func TestRotatingFileHandler_Handle_ExistingFile(t *testing.T) {
+	t.Parallel()
+
+	info := &memoryFileInfo{name: "app.log", size: 15, modTime: time.Date(2020, 8, 24, 10, 0, 0, 0, time.UTC)}
+
+	f := &mockFile{}
+	f.On("Write", []byte("message\n")).Return(8, nil)
+	f.On("Close").Return(nil)
+
+	fs := &mockFilesystem{}
+	fs.On("Stat", "/var/log/app.log").Return(info, nil).Once()
+	fs.On("OpenFile", "/var/log/app.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, os.FileMode(0644)).Return(f, nil)
+	fs.On("Stat", "/var/log/app.log.2020-08-25T19-06-36.000").Return(nil, os.ErrNotExist)
+	fs.On("Rename", "/var/log/app.log", "/var/log/app.log.2020-08-25T19-06-36.000").Return(nil)
+	fs.On("Stat", "/var/log/app.log").Return(nil, os.ErrNotExist).Once()
+
+	clock := newManualClock(time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC))
+	fh := NewRotatingFileHandler("/var/log/app.log", 0, RotateDaily).WithFilesystem(fs).WithClock(clock)
+
+	assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "message"}))
+
+	f.AssertExpectations(t)
+	fs.AssertExpectations(t)
+}
+
+func TestRotatingFileHandler_Reopen(t *testing.T) {
+	t.Parallel()
+
+	info := &memoryFileInfo{name: "app.log", size: 15, modTime: time.Date(2020, 8, 25, 10, 0, 0, 0, time.UTC)}
+
+	f1 := &mockFile{}
+	f1.On("Write", []byte("first\n")).Return(6, nil)
+	f1.On("Close").Return(nil)
+
+	f2 := &mockFile{}
+	f2.On("Write", []byte("second\n")).Return(7, nil)
+
+	fs := &mockFilesystem{}
+	fs.On("Stat", "/var/log/app.log").Return(nil, os.ErrNotExist).Once()
+	fs.On("OpenFile", "/var/log/app.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, os.FileMode(0644)).Return(f1, nil).Once()
+	fs.On("Stat", "/var/log/app.log").Return(info, nil).Once()
+	fs.On("OpenFile", "/var/log/app.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, os.FileMode(0644)).Return(f2, nil).Once()
+
+	clock := newManualClock(time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC))
+	fh := NewRotatingFileHandler("/var/log/app.log", 0, RotateDaily).WithFilesystem(fs).WithClock(clock)
+
+	assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "first"}))
+	assert.NoError(t, fh.Reopen())
+	assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "second"}))
+
+	f1.AssertExpectations(t)
+	f2.AssertExpectations(t)
+	fs.AssertExpectations(t)
+}
+
+func TestRotatingFileHandler_Handle_Failure(t *testing.T) {
+	t.Parallel()
+
+	fs := &mockFilesystem{}
+	fs.On("Stat", "/var/log/app.log").Return(nil, errors.New("test"))
+
+	fh := NewRotatingFileHandler("/var/log/app.log", 0, RotateDaily).WithFilesystem(fs)
+	err := fh.Handle(Log{Level: LevelInfo, Message: "message"})
+
+	if assert.Error(t, err) {
+		assert.EqualError(t, err, "RotatingFileHandler - unable to read log file info: test")
+		fs.AssertExpectations(t)
+	}
+}
+
+func TestRotatingFileHandler_Rotate_Compression(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock(time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC))
+	fs := newMemoryFilesystem(clock)
+
+	fh := NewRotatingFileHandler("/var/log/app.log", 0, RotateNever).
+		WithFilesystem(fs).
+		WithClock(clock).
+		WithCompression(NewGzipCompressor(gzip.DefaultCompression)).
+		WithMaxBackups(2)
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "message"}))
+		assert.NoError(t, fh.Rotate())
+		fh.Wait()
+	}
+
+	assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "message"}))
+	assert.NoError(t, fh.Rotate())
+	assert.NoError(t, fh.Close())
+
+	assert.Equal(t, []string{
+		"/var/log/app.log.2020-08-25T19-06-36.000.1.gz",
+		"/var/log/app.log.2020-08-25T19-06-36.000.2.gz",
+	}, fs.names())
+
+	content, _ := fs.content("/var/log/app.log.2020-08-25T19-06-36.000.2.gz")
+	reader, rErr := gzip.NewReader(strings.NewReader(content))
+
+	if assert.NoError(t, rErr) {
+		uncompressed, _ := ioutil.ReadAll(reader)
+		assert.Equal(t, "message\n", string(uncompressed))
+	}
+}
+
+func TestRotatingFileHandler_Rotate_CompressionFailure(t *testing.T) {
+	t.Parallel()
+
+	var failedLog Log
+	var failure error
+
+	clock := newManualClock(time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC))
+	fs := newMemoryFilesystem(clock)
+
+	mCompressor := &mockCompressor{}
+	mCompressor.On("Extension").Return(".test")
+	mCompressor.On("Compress", mock.Anything, mock.Anything).Return(errors.New("test"))
+
+	fh := NewRotatingFileHandler("/var/log/app.log", 0, RotateNever).
+		WithFilesystem(fs).
+		WithClock(clock).
+		WithCompression(mCompressor).
+		WithFailureHandler(func(log Log, err error) {
+			failedLog = log
+			failure = err
+		})
+
+	assert.NoError(t, fh.Handle(Log{Level: LevelInfo, Message: "message"}))
+	assert.NoError(t, fh.Rotate())
+	assert.NoError(t, fh.Close())
+
+	assert.Equal(t, []string{"/var/log/app.log.2020-08-25T19-06-36.000"}, fs.names())
+	assert.Equal(t, Data{"path": "/var/log/app.log.2020-08-25T19-06-36.000"}, failedLog.Data)
+	assert.EqualError(t, failure, "RotatingFileHandler - unable to write compressed file: test")
+}
+
+func TestParseBackupName(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC)
+
+	valid := map[string]int{
+		"2020-08-25T19-06-36.000":      0,
+		"2020-08-25T19-06-36.000.3":    3,
+		"2020-08-25T19-06-36.000.gz":   0,
+		"2020-08-25T19-06-36.000.3.gz": 3,
+	}
+
+	for suffix, sequence := range valid {
+		backup, ok := parseBackupName("app.log."+suffix, suffix)
+
+		if assert.True(t, ok, suffix) {
+			assert.Equal(t, createdAt, backup.createdAt)
+			assert.Equal(t, sequence, backup.sequence)
+		}
+	}
+
+	for _, suffix := range []string{"old", "2020-08-25", "2020-08-25T19-06-36.000.gz.tmp", "2020-08-25T19-06-36.000x"} {
+		_, ok := parseBackupName("app.log."+suffix, suffix)
+		assert.False(t, ok, suffix)
+	}
+}
@@ -0,0 +1,189 @@
+package logger
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type samplingKey struct {
+	level   Level
+	message string
+}
+
+type samplingCounter struct {
+	seen       uint64
+	suppressed uint64
+}
+
+// SamplingHandler limits number of logs with the same level and message
+// template. In each time window first N logs are passed to wrapped handler
+// and after that only every Mth log. When window ends, summary log with
+// number of suppressed logs is passed to wrapped handler with the next
+// log or by Flush (e.g. called periodically) and Close.
+type SamplingHandler struct {
+	handler     Handler
+	clock       Clock
+	window      time.Duration
+	first       uint64
+	thereafter  uint64
+	lock        sync.Mutex
+	windowStart time.Time
+	counters    map[samplingKey]*samplingCounter
+}
+
+// NewSamplingHandler creates SamplingHandler which passes "first" logs
+// in each "window" and then every "thereafter" log (zero - none).
+func NewSamplingHandler(handler Handler, window time.Duration, first uint, thereafter uint) *SamplingHandler {
+	return &SamplingHandler{
+		handler:    handler,
+		clock:      NewDefaultClock(),
+		window:     window,
+		first:      uint64(first),
+		thereafter: uint64(thereafter),
+		counters:   make(map[samplingKey]*samplingCounter),
+	}
+}
+
+// WithClock allows to set custom implementation for
+// Clock interface used for sampling windows.
+func (h *SamplingHandler) WithClock(clock Clock) *SamplingHandler {
+	h.clock = clock
+
+	return h
+}
+
+func (h *SamplingHandler) Handle(log Log) error {
+	var err error
+	logs := h.sample([]Log{log})
+
+	switch {
+	case len(logs) == 1:
+		err = h.handler.Handle(logs[0])
+	case len(logs) > 1:
+		err = h.handler.HandleBatch(logs)
+	}
+
+	if err != nil {
+		return fmt.Errorf("SamplingHandler - wrapped handler returned an error: %w", err)
+	}
+
+	return nil
+}
+
+func (h *SamplingHandler) HandleBatch(logs []Log) error {
+	sampled := h.sample(logs)
+
+	if len(sampled) == 0 {
+		return nil
+	}
+
+	err := h.handler.HandleBatch(sampled)
+
+	if err != nil {
+		return fmt.Errorf("SamplingHandler - wrapped handler returned an error: %w", err)
+	}
+
+	return nil
+}
+
+// Flush ends current window and passes summaries of suppressed
+// logs to wrapped handler, so they are not lost when logs stop coming.
+func (h *SamplingHandler) Flush() error {
+	h.lock.Lock()
+	summaries := h.rotateWindow(h.clock.Now(), true)
+	h.lock.Unlock()
+
+	if len(summaries) == 0 {
+		return nil
+	}
+
+	err := h.handler.HandleBatch(summaries)
+
+	if err != nil {
+		return fmt.Errorf("SamplingHandler - wrapped handler returned an error: %w", err)
+	}
+
+	return nil
+}
+
+// Close passes summaries of current window to wrapped handler.
+// Wrapped handler is not closed.
+func (h *SamplingHandler) Close() error {
+	return h.Flush()
+}
+
+// sample returns logs which should be passed to wrapped
+// handler preceded by summaries of finished window.
+func (h *SamplingHandler) sample(logs []Log) []Log {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	now := h.clock.Now()
+	res := h.rotateWindow(now, false)
+
+	for _, log := range logs {
+		key := samplingKey{level: log.Level, message: log.Message}
+		counter, ok := h.counters[key]
+
+		if !ok {
+			counter = &samplingCounter{}
+			h.counters[key] = counter
+		}
+
+		counter.seen++
+
+		if counter.seen <= h.first || (h.thereafter > 0 && (counter.seen-h.first)%h.thereafter == 0) {
+			res = append(res, log)
+		} else {
+			counter.suppressed++
+		}
+	}
+
+	return res
+}
+
+// rotateWindow starts new window when current one ended (or when "force"
+// is true) and returns summary logs for suppressed messages.
+func (h *SamplingHandler) rotateWindow(now time.Time, force bool) []Log {
+	if !force && !h.windowStart.IsZero() && now.Before(h.windowStart.Add(h.window)) {
+		return nil
+	}
+
+	var summaries []Log
+
+	for key, counter := range h.counters {
+		if counter.suppressed == 0 {
+			continue
+		}
+
+		summaries = append(summaries, Log{
+			Level:   key.level,
+			Message: fmt.Sprintf("SamplingHandler - suppressed %d logs: %s", counter.suppressed, key.message),
+			Data: Data{
+				"suppressed": strconv.FormatUint(counter.suppressed, 10),
+				"message":    key.message,
+			},
+			CreatedAt: now,
+		})
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Level == summaries[j].Level {
+			return summaries[i].Data["message"] < summaries[j].Data["message"]
+		}
+
+		return summaries[i].Level < summaries[j].Level
+	})
+
+	h.windowStart = now
+	h.counters = make(map[samplingKey]*samplingCounter)
+
+	return summaries
+}
+
+func (h *SamplingHandler) children() []Handler {
+	return []Handler{h.handler}
+}
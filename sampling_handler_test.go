@@ -0,0 +1,113 @@
+package logger
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSamplingHandler_Handle(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock(time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC))
+	memory := NewInMemoryHandler(0)
+	handler := NewSamplingHandler(memory, time.Second, 2, 3).WithClock(clock)
+
+	for i := 1; i <= 10; i++ {
+		assert.NoError(t, handler.Handle(Log{Level: LevelWarning, Message: "cache miss {key}", Data: Data{"key": "k"}}))
+	}
+
+	assert.NoError(t, handler.Handle(Log{Level: LevelError, Message: "cache miss {key}", Data: Data{"key": "k"}}))
+
+	// logs: 1, 2, 5, 8 of warning and 1 of error
+	assert.Equal(t, LevelError, memory.Pop().Level)
+
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, LevelWarning, memory.Pop().Level)
+	}
+
+	assert.True(t, memory.IsEmpty())
+
+	clock.Add(time.Second)
+	assert.NoError(t, handler.Handle(Log{Level: LevelWarning, Message: "cache miss {key}", Data: Data{"key": "k"}}))
+
+	assert.Equal(t, "cache miss {key}", memory.Pop().Message)
+	assert.Equal(t, Log{
+		Level:     LevelWarning,
+		Message:   "SamplingHandler - suppressed 6 logs: cache miss {key}",
+		Data:      Data{"suppressed": "6", "message": "cache miss {key}"},
+		CreatedAt: clock.Now(),
+	}, memory.Pop())
+	assert.True(t, memory.IsEmpty())
+}
+
+func TestSamplingHandler_Flush(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock(time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC))
+	memory := NewInMemoryHandler(0)
+	handler := NewSamplingHandler(memory, time.Second, 1, 0).WithClock(clock)
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, handler.Handle(Log{Level: LevelInfo, Message: "test"}))
+	}
+
+	assert.Equal(t, "test", memory.Pop().Message)
+	assert.True(t, memory.IsEmpty())
+
+	assert.NoError(t, handler.Flush())
+	assert.Equal(t, "SamplingHandler - suppressed 2 logs: test", memory.Pop().Message)
+	assert.True(t, memory.IsEmpty())
+
+	// new window is started by Flush
+	assert.NoError(t, handler.Handle(Log{Level: LevelInfo, Message: "test"}))
+	assert.NoError(t, handler.Handle(Log{Level: LevelInfo, Message: "test"}))
+	assert.Equal(t, "test", memory.Pop().Message)
+
+	assert.NoError(t, handler.Close())
+	assert.Equal(t, "SamplingHandler - suppressed 1 logs: test", memory.Pop().Message)
+
+	assert.NoError(t, handler.Flush())
+	assert.True(t, memory.IsEmpty())
+}
+
+func TestSamplingHandler_HandleBatch(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock(time.Date(2020, 8, 25, 19, 6, 36, 0, time.UTC))
+	memory := NewInMemoryHandler(0)
+	handler := NewSamplingHandler(memory, time.Minute, 1, 0).WithClock(clock)
+
+	logs := []Log{
+		{Level: LevelInfo, Message: "first"},
+		{Level: LevelInfo, Message: "first"},
+		{Level: LevelInfo, Message: "second"},
+		{Level: LevelInfo, Message: "first"},
+	}
+
+	assert.NoError(t, handler.HandleBatch(logs))
+	assert.Equal(t, "second", memory.Pop().Message)
+	assert.Equal(t, "first", memory.Pop().Message)
+	assert.True(t, memory.IsEmpty())
+
+	assert.NoError(t, handler.HandleBatch(logs[:1]))
+	assert.True(t, memory.IsEmpty())
+}
+
+func TestSamplingHandler_Handle_Failure(t *testing.T) {
+	t.Parallel()
+
+	log := Log{Level: LevelInfo, Message: "test", Data: make(Data), CreatedAt: time.Now()}
+
+	mHandler := &mockHandler{}
+	mHandler.On("Handle", log).Return(errors.New("test"))
+
+	handler := NewSamplingHandler(mHandler, time.Minute, 1, 0)
+	err := handler.Handle(log)
+
+	if assert.Error(t, err) {
+		assert.EqualError(t, err, "SamplingHandler - wrapped handler returned an error: test")
+		mHandler.AssertExpectations(t)
+	}
+}
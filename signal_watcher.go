@@ -0,0 +1,145 @@
+//go:build !windows
+
+package logger
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// SignalWatcher changes MainLogger level and reopens log files on OS signals:
+//   - SIGUSR1 lowers level by one step (to the closest registered level),
+//     after the lowest level it goes back to the level from Start,
+//   - SIGUSR2 resets level to the level from Start,
+//   - SIGHUP reopens files of all handlers provided with WithReopen. When none
+//     was provided, all handlers implementing Reopener found in logger handler
+//     tree (e.g. FileHandler, RotatingFileHandler) are reopened.
+type SignalWatcher struct {
+	logger         *MainLogger
+	reopeners      []Reopener
+	failureHandler FailureHandleFunc
+	lock           sync.Mutex
+	initialLevel   Level
+	signals        chan os.Signal
+	done           chan struct{}
+	wg             sync.WaitGroup
+}
+
+func NewSignalWatcher(logger *MainLogger) *SignalWatcher {
+	return &SignalWatcher{logger: logger}
+}
+
+// WithReopen adds handlers (e.g. FileHandler) reopened on SIGHUP
+// instead of handlers found in logger handler tree.
+func (w *SignalWatcher) WithReopen(handlers ...Reopener) *SignalWatcher {
+	w.reopeners = append(w.reopeners, handlers...)
+
+	return w
+}
+
+// WithFailureHandler sets function called when one of handlers cannot be reopened.
+func (w *SignalWatcher) WithFailureHandler(handleFunc FailureHandleFunc) *SignalWatcher {
+	w.failureHandler = handleFunc
+
+	return w
+}
+
+// Start installs signal handlers. Current logger level is used as reset level.
+func (w *SignalWatcher) Start() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.signals != nil {
+		return
+	}
+
+	w.initialLevel = w.logger.Level()
+	w.signals = make(chan os.Signal, 1)
+	w.done = make(chan struct{})
+
+	signal.Notify(w.signals, syscall.SIGUSR1, syscall.SIGUSR2, syscall.SIGHUP)
+
+	w.wg.Add(1)
+
+	go w.watch(w.signals, w.done)
+}
+
+// Stop removes signal handlers and waits until current signal is processed.
+func (w *SignalWatcher) Stop() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.signals == nil {
+		return
+	}
+
+	signal.Stop(w.signals)
+	close(w.done)
+	w.wg.Wait()
+
+	w.signals = nil
+}
+
+func (w *SignalWatcher) watch(signals <-chan os.Signal, done <-chan struct{}) {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case sig := <-signals:
+			w.handleSignal(sig)
+		case <-done:
+			return
+		}
+	}
+}
+
+func (w *SignalWatcher) handleSignal(sig os.Signal) {
+	switch sig {
+	case syscall.SIGUSR1:
+		level, ok := lowerLevel(w.logger.Level())
+
+		if !ok {
+			level = w.initialLevel
+		}
+
+		w.logger.SetLevel(level)
+	case syscall.SIGUSR2:
+		w.logger.SetLevel(w.initialLevel)
+	case syscall.SIGHUP:
+		reopeners := w.reopeners
+
+		if len(reopeners) == 0 {
+			reopeners = findReopeners(w.logger.handler, w.logger.settings.get().fallbackHandler)
+		}
+
+		for _, reopener := range reopeners {
+			err := reopener.Reopen()
+
+			if err != nil && w.failureHandler != nil {
+				w.failureHandler(Log{
+					Level:     LevelError,
+					Message:   "SignalWatcher - unable to reopen log file",
+					Data:      Data{"handler": fmt.Sprintf("%T", reopener)},
+					CreatedAt: w.logger.settings.get().clock.Now(),
+				}, err)
+			}
+		}
+	}
+}
+
+// findReopeners returns handlers implementing Reopener from handler trees.
+// Handler used in many trees is returned only once.
+func findReopeners(handlers ...Handler) []Reopener {
+	var res []Reopener
+
+	for _, h := range handlerTree(handlers...) {
+		if reopener, ok := h.(Reopener); ok {
+			res = append(res, reopener)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,156 @@
+//go:build !windows
+
+package logger
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// Signals are delivered to the whole process, so tests below are
+// not parallel to avoid interference with other signal watchers.
+
+func TestSignalWatcher_Level(t *testing.T) {
+	logger := New(NewInMemoryHandler(0))
+	logger.SetLevel(LevelWarning)
+
+	watcher := NewSignalWatcher(logger)
+	watcher.Start()
+	defer watcher.Stop()
+
+	// custom levels registered by other tests are also steps
+	var steps []Level
+
+	for level, ok := lowerLevel(LevelWarning); ok; level, ok = lowerLevel(level) {
+		steps = append(steps, level)
+	}
+
+	assert.Contains(t, steps, LevelInfo)
+	assert.Equal(t, LevelDebug, steps[len(steps)-1])
+
+	for _, expected := range append(steps, LevelWarning, steps[0]) {
+		assert.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+		assert.Eventually(t, func() bool {
+			return logger.Level() == expected
+		}, time.Second, time.Millisecond, expected)
+	}
+
+	assert.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR2))
+	assert.Eventually(t, func() bool {
+		return logger.Level() == LevelWarning
+	}, time.Second, time.Millisecond)
+}
+
+func TestSignalWatcher_Reopen(t *testing.T) {
+	dir, dErr := ioutil.TempDir("", "logger")
+
+	if !assert.NoError(t, dErr) {
+		return
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "app.log")
+	fh := NewFileHandler(path)
+	defer fh.Close()
+
+	var failures []error
+	var failureLogs []Log
+	failing := &mockReopener{err: errors.New("test")}
+
+	watcher := NewSignalWatcher(New(fh)).WithReopen(fh, failing).WithFailureHandler(func(log Log, err error) {
+		failures = append(failures, err)
+		failureLogs = append(failureLogs, log)
+	})
+	watcher.Start()
+
+	assert.NoError(t, fh.Handle(Log{Message: "first"}))
+	assert.NoError(t, os.Rename(path, path+".1"))
+	assert.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+
+		return err == nil
+	}, time.Second, time.Millisecond)
+
+	watcher.Stop()
+
+	assert.NoError(t, fh.Handle(Log{Message: "second"}))
+
+	rotated, _ := ioutil.ReadFile(path + ".1")
+	current, _ := ioutil.ReadFile(path)
+
+	assert.Equal(t, "first\n", string(rotated))
+	assert.Equal(t, "second\n", string(current))
+	assert.Equal(t, []error{failing.err}, failures)
+
+	if assert.Len(t, failureLogs, 1) {
+		assert.Equal(t, "*logger.mockReopener", failureLogs[0].Data["handler"])
+	}
+}
+
+func TestSignalWatcher_Reopen_HandlerTree(t *testing.T) {
+	dir, dErr := ioutil.TempDir("", "logger")
+
+	if !assert.NoError(t, dErr) {
+		return
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "app.log")
+	rfh := NewRotatingFileHandler(path, 0, RotateNever)
+	defer rfh.Close()
+
+	l := New(NewMultiHandler(NewInMemoryHandler(0), NewLevelGroupedHandler(rfh)))
+	watcher := NewSignalWatcher(l)
+	watcher.Start()
+
+	l.Info("first")
+	assert.NoError(t, os.Rename(path, path+".1"))
+	assert.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+
+		return err == nil
+	}, time.Second, time.Millisecond)
+
+	watcher.Stop()
+
+	l.Info("second")
+
+	rotated, _ := ioutil.ReadFile(path + ".1")
+	current, _ := ioutil.ReadFile(path)
+
+	assert.Equal(t, "first\n", string(rotated))
+	assert.Equal(t, "second\n", string(current))
+}
+
+func TestFindReopeners(t *testing.T) {
+	fh := NewFileHandler("/var/log/app.log")
+	rfh := NewRotatingFileHandler("/var/log/rotating.log", 0, RotateNever)
+	deadLetter := NewFileHandler("/var/log/dead-letter.log")
+
+	handler := &reloadableHandler{handler: NewMultiHandler(
+		NewInMemoryHandler(0),
+		NewLevelGroupedHandler(fh),
+		NewRetryHandler(rfh, 1, 0, 0).WithDeadLetterHandler(deadLetter),
+	)}
+
+	assert.Equal(t, []Reopener{fh, rfh, deadLetter}, findReopeners(handler))
+	assert.Equal(t, []Reopener{fh, rfh, deadLetter}, findReopeners(handler, fh, nil))
+}
+
+type mockReopener struct {
+	err error
+}
+
+func (m *mockReopener) Reopen() error {
+	return m.err
+}
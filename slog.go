@@ -0,0 +1,320 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"time"
+)
+
+// SlogAdapter makes any Handler usable as slog.Handler. Slog levels
+// are mapped to LevelDebug..LevelCritical and attributes (including
+// groups as nested objects) are passed to Handler as Log.Fields.
+type SlogAdapter struct {
+	handler Handler
+	level   slog.Leveler
+	attrs   []slogGroupedAttr
+	groups  []string
+}
+
+type slogGroupedAttr struct {
+	groups []string
+	attr   slog.Attr
+}
+
+// NewSlogAdapter creates SlogAdapter which passes all records
+// with slog.LevelDebug level or above to provided handler.
+func NewSlogAdapter(handler Handler) *SlogAdapter {
+	return &SlogAdapter{handler: handler, level: slog.LevelDebug}
+}
+
+// WithLevel changes minimum level of records passed to handler.
+func (a *SlogAdapter) WithLevel(level slog.Leveler) *SlogAdapter {
+	a.level = level
+
+	return a
+}
+
+func (a *SlogAdapter) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= a.level.Level()
+}
+
+func (a *SlogAdapter) Handle(ctx context.Context, record slog.Record) error {
+	var fields Fields
+
+	for _, ga := range a.attrs {
+		fields = appendSlogAttr(fields, ga.groups, ga.attr)
+	}
+
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendSlogAttr(fields, a.groups, attr)
+
+		return true
+	})
+
+	log := Log{
+		Level:     levelFromSlog(record.Level),
+		Message:   record.Message,
+		Data:      make(Data),
+		Fields:    fields,
+		CreatedAt: record.Time,
+		Context:   ctx,
+	}
+
+	if record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		log.Caller = &Caller{File: frame.File, Line: frame.Line, Function: frame.Function}
+	}
+
+	err := a.handler.Handle(log)
+
+	if err != nil {
+		return fmt.Errorf("SlogAdapter - handler returned an error: %w", err)
+	}
+
+	return nil
+}
+
+func (a *SlogAdapter) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return a
+	}
+
+	child := a.clone()
+
+	for _, attr := range attrs {
+		child.attrs = append(child.attrs, slogGroupedAttr{groups: a.groups, attr: attr})
+	}
+
+	return child
+}
+
+func (a *SlogAdapter) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return a
+	}
+
+	child := a.clone()
+	child.groups = append(child.groups, name)
+
+	return child
+}
+
+func (a *SlogAdapter) clone() *SlogAdapter {
+	return &SlogAdapter{
+		handler: a.handler,
+		level:   a.level,
+		attrs:   a.attrs[:len(a.attrs):len(a.attrs)],
+		groups:  a.groups[:len(a.groups):len(a.groups)],
+	}
+}
+
+// SlogHandler passes all incoming logs to slog.Handler.
+// Data and Fields (and logger name as "logger") are passed as record attributes.
+type SlogHandler struct {
+	handler slog.Handler
+}
+
+func NewSlogHandler(handler slog.Handler) *SlogHandler {
+	return &SlogHandler{handler: handler}
+}
+
+func (h *SlogHandler) Handle(log Log) error {
+	ctx := log.Context
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	level := levelToSlog(log.Level)
+
+	if !h.handler.Enabled(ctx, level) {
+		return nil
+	}
+
+	record := slog.NewRecord(log.CreatedAt, level, applyDataOnMessage(log.Message, log.Data.withFields(log.Fields)), 0)
+
+	if log.Name != "" {
+		record.AddAttrs(slog.String("logger", log.Name))
+	}
+
+	for _, key := range sortedDataKeys(log.Data) {
+		record.AddAttrs(slog.String(key, log.Data[key]))
+	}
+
+	for _, field := range log.Fields {
+		record.AddAttrs(fieldToSlogAttr(field))
+	}
+
+	err := h.handler.Handle(ctx, record)
+
+	if err != nil {
+		return fmt.Errorf("SlogHandler - error occurred while handling log: %w", err)
+	}
+
+	return nil
+}
+
+func (h *SlogHandler) HandleBatch(logs []Log) error {
+	for _, log := range logs {
+		err := h.Handle(log)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appendSlogAttr adds attribute converted to Field to fields,
+// nesting it in Object fields for each group.
+func appendSlogAttr(fields Fields, groups []string, attr slog.Attr) Fields {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if attr.Value.Kind() == slog.KindGroup && attr.Key == "" {
+		for _, ga := range attr.Value.Group() {
+			fields = appendSlogAttr(fields, groups, ga)
+		}
+
+		return fields
+	}
+
+	if attr.Value.Kind() == slog.KindGroup && len(attr.Value.Group()) == 0 {
+		return fields
+	}
+
+	if len(groups) == 0 {
+		return append(fields, slogAttrToField(attr))
+	}
+
+	for idx, field := range fields {
+		if field.Type == FieldTypeObject && field.Key == groups[0] {
+			nested := appendSlogAttr(field.Value.(Fields), groups[1:], attr)
+			res := append(Fields{}, fields...)
+			res[idx] = Object(groups[0], nested...)
+
+			return res
+		}
+	}
+
+	return append(fields, Object(groups[0], appendSlogAttr(nil, groups[1:], attr)...))
+}
+
+func slogAttrToField(attr slog.Attr) Field {
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		return String(attr.Key, attr.Value.String())
+	case slog.KindInt64:
+		return Int64(attr.Key, attr.Value.Int64())
+	case slog.KindUint64:
+		return Any(attr.Key, attr.Value.Uint64())
+	case slog.KindFloat64:
+		return Float(attr.Key, attr.Value.Float64())
+	case slog.KindBool:
+		return Bool(attr.Key, attr.Value.Bool())
+	case slog.KindDuration:
+		return Duration(attr.Key, attr.Value.Duration())
+	case slog.KindTime:
+		return Time(attr.Key, attr.Value.Time())
+	case slog.KindGroup:
+		var nested Fields
+
+		for _, ga := range attr.Value.Group() {
+			nested = appendSlogAttr(nested, nil, ga)
+		}
+
+		return Object(attr.Key, nested...)
+	default:
+		if err, ok := attr.Value.Any().(error); ok {
+			return Field{Key: attr.Key, Type: FieldTypeError, Value: err}
+		}
+
+		return Any(attr.Key, attr.Value.Any())
+	}
+}
+
+// fieldToSlogAttr converts field to slog.Attr. Values with type other
+// than expected by field type are converted with slog.Any.
+func fieldToSlogAttr(field Field) slog.Attr {
+	switch val := field.Value.(type) {
+	case string:
+		if field.Type == FieldTypeString {
+			return slog.String(field.Key, val)
+		}
+	case int64:
+		if field.Type == FieldTypeInt {
+			return slog.Int64(field.Key, val)
+		}
+	case float64:
+		if field.Type == FieldTypeFloat {
+			return slog.Float64(field.Key, val)
+		}
+	case bool:
+		if field.Type == FieldTypeBool {
+			return slog.Bool(field.Key, val)
+		}
+	case time.Duration:
+		if field.Type == FieldTypeDuration {
+			return slog.Duration(field.Key, val)
+		}
+	case time.Time:
+		if field.Type == FieldTypeTime {
+			return slog.Time(field.Key, val)
+		}
+	case Fields:
+		if field.Type == FieldTypeObject {
+			var attrs []any
+
+			for _, nested := range val {
+				attrs = append(attrs, fieldToSlogAttr(nested))
+			}
+
+			return slog.Group(field.Key, attrs...)
+		}
+	}
+
+	if field.Type == FieldTypeStringer {
+		return slog.String(field.Key, field.String())
+	}
+
+	return slog.Any(field.Key, field.Value)
+}
+
+// levelFromSlog maps slog level to the closest Level.
+func levelFromSlog(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return LevelDebug
+	case level < slog.LevelWarn:
+		return LevelInfo
+	case level < slog.LevelError:
+		return LevelWarning
+	case level < slog.LevelError+4:
+		return LevelError
+	default:
+		return LevelCritical
+	}
+}
+
+// levelToSlog maps Level to the closest slog level. LevelCritical
+// (and above) is mapped to level higher than slog.LevelError.
+func levelToSlog(level Level) slog.Level {
+	switch {
+	case level < LevelInfo:
+		return slog.LevelDebug
+	case level < LevelWarning:
+		return slog.LevelInfo
+	case level < LevelError:
+		return slog.LevelWarn
+	case level < LevelCritical:
+		return slog.LevelError
+	default:
+		return slog.LevelError + 4
+	}
+}
@@ -0,0 +1,140 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSlogAdapter_Handle(t *testing.T) {
+	t.Parallel()
+
+	handler := NewInMemoryHandler(0)
+	sl := slog.New(NewSlogAdapter(handler))
+
+	sl.With("service", "api").
+		WithGroup("request").
+		With("id", "abc").
+		Info("test message", "status", 200, slog.Group("user", "name", "john"), "took", time.Second)
+
+	log := handler.Pop()
+
+	assert.Equal(t, LevelInfo, log.Level)
+	assert.Equal(t, "test message", log.Message)
+	assert.Equal(t, Data{}, log.Data)
+	assert.Equal(t, Fields{
+		String("service", "api"),
+		Object("request",
+			String("id", "abc"),
+			Int64("status", 200),
+			Object("user", String("name", "john")),
+			Duration("took", time.Second),
+		),
+	}, log.Fields)
+
+	if assert.NotNil(t, log.Caller) {
+		assert.Equal(t, packagePath+".TestSlogAdapter_Handle", log.Caller.Function)
+	}
+}
+
+func TestSlogAdapter_Levels(t *testing.T) {
+	t.Parallel()
+
+	handler := NewInMemoryHandler(0)
+	sl := slog.New(NewSlogAdapter(handler).WithLevel(slog.LevelInfo))
+	ctx := context.Background()
+
+	sl.Debug("debug")
+	assert.True(t, handler.IsEmpty())
+
+	sl.Info("info")
+	sl.Warn("warning")
+	sl.Error("error", "err", errors.New("test"))
+	sl.Log(ctx, slog.LevelError+4, "critical")
+
+	assert.Equal(t, LevelCritical, handler.Pop().Level)
+
+	errorLog := handler.Pop()
+	assert.Equal(t, LevelError, errorLog.Level)
+	assert.Equal(t, Fields{{Key: "err", Type: FieldTypeError, Value: errors.New("test")}}, errorLog.Fields)
+
+	assert.Equal(t, LevelWarning, handler.Pop().Level)
+	assert.Equal(t, LevelInfo, handler.Pop().Level)
+}
+
+func TestSlogAdapter_Handle_Failure(t *testing.T) {
+	t.Parallel()
+
+	mHandler := &mockHandler{}
+	mHandler.On("Handle", mock.IsType(Log{})).Return(errors.New("test"))
+
+	err := NewSlogAdapter(mHandler).Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "test", 0))
+
+	if assert.Error(t, err) {
+		assert.EqualError(t, err, "SlogAdapter - handler returned an error: test")
+		mHandler.AssertExpectations(t)
+	}
+}
+
+func TestSlogHandler_Handle(t *testing.T) {
+	t.Parallel()
+
+	buff := &bytes.Buffer{}
+	sh := slog.NewTextHandler(buff, &slog.HandlerOptions{Level: slog.LevelInfo})
+
+	logger := New(NewSlogHandler(sh))
+	logger.Debug("test debug")
+	logger.Info("user {user} logged in", "user", "john")
+	logger.Errorw("request failed", Int("status", 500), Object("request", String("id", "abc")), Err(errors.New("test")))
+	logger.Critical("test critical")
+
+	lines := bytes.Split(bytes.TrimSpace(buff.Bytes()), []byte("\n"))
+
+	if assert.Len(t, lines, 3) {
+		assert.Contains(t, string(lines[0]), `level=INFO msg="user john logged in" user=john`)
+		assert.Contains(t, string(lines[1]), `level=ERROR msg="request failed" status=500 request.id=abc error=test`)
+		assert.Contains(t, string(lines[2]), `level=ERROR+4 msg="test critical"`)
+	}
+}
+
+func TestSlogHandler_Handle_UnexpectedFieldValues(t *testing.T) {
+	t.Parallel()
+
+	buff := &bytes.Buffer{}
+	logger := New(NewSlogHandler(slog.NewTextHandler(buff, nil)))
+
+	logger.Infow("test",
+		Field{Key: "int", Type: FieldTypeInt, Value: 5},
+		Field{Key: "string", Type: FieldTypeString},
+		Field{Key: "time", Type: FieldTypeTime, Value: "now"},
+		Field{Key: "object", Type: FieldTypeObject, Value: []Field{}},
+	)
+
+	assert.Contains(t, buff.String(), `msg=test int=5 string=<nil> time=now object=[]`)
+}
+
+func TestSlogHandler_HandleBatch(t *testing.T) {
+	t.Parallel()
+
+	buff := &bytes.Buffer{}
+	handler := NewSlogHandler(slog.NewTextHandler(buff, nil))
+
+	err := handler.HandleBatch([]Log{
+		{Level: LevelInfo, Message: "test 1", Data: make(Data), CreatedAt: time.Now()},
+		{Level: LevelWarning, Message: "test 2", Data: Data{"key": "val"}, CreatedAt: time.Now()},
+	})
+
+	if assert.NoError(t, err) {
+		lines := bytes.Split(bytes.TrimSpace(buff.Bytes()), []byte("\n"))
+
+		if assert.Len(t, lines, 2) {
+			assert.Contains(t, string(lines[0]), `level=INFO msg="test 1"`)
+			assert.Contains(t, string(lines[1]), `level=WARN msg="test 2" key=val`)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+)
+
+// StackTrace contains frames of goroutine stack
+// starting from the most recent call.
+type StackTrace []Caller
+
+var callerType = reflect.TypeOf(Caller{})
+
+// captureStackTrace returns stack trace of current goroutine
+// without logger functions and additional "skip" frames.
+func captureStackTrace(skip int) StackTrace {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack StackTrace
+
+	for {
+		frame, more := frames.Next()
+
+		if !isInternalCaller(frame.Function) && frame.Function != "runtime.goexit" {
+			if skip <= 0 {
+				stack = append(stack, Caller{File: frame.File, Line: frame.Line, Function: frame.Function})
+			} else {
+				skip--
+			}
+		}
+
+		if !more {
+			return stack
+		}
+	}
+}
+
+// extractStackTrace looks for the first error in chain which
+// exposes "StackTrace()" method returning slice of program
+// counters (e.g. errors created with github.com/pkg/errors)
+// or StackTrace and converts it to StackTrace.
+func extractStackTrace(err error) StackTrace {
+	for ; err != nil; err = errors.Unwrap(err) {
+		method := reflect.ValueOf(err).MethodByName("StackTrace")
+
+		if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+			continue
+		}
+
+		result := method.Call(nil)[0]
+
+		if result.Kind() != reflect.Slice {
+			continue
+		}
+
+		switch result.Type().Elem().Kind() {
+		case reflect.Uintptr:
+			pcs := make([]uintptr, result.Len())
+
+			for i := range pcs {
+				pcs[i] = uintptr(result.Index(i).Uint())
+			}
+
+			return stackTraceFromPCs(pcs)
+		case reflect.Struct:
+			if result.Type().Elem() == callerType {
+				return result.Convert(reflect.TypeOf(StackTrace{})).Interface().(StackTrace)
+			}
+		}
+	}
+
+	return nil
+}
+
+func stackTraceFromPCs(pcs []uintptr) StackTrace {
+	var stack StackTrace
+
+	if len(pcs) == 0 {
+		return stack
+	}
+
+	frames := runtime.CallersFrames(pcs)
+
+	for {
+		frame, more := frames.Next()
+
+		if frame.Function != "runtime.goexit" {
+			stack = append(stack, Caller{File: frame.File, Line: frame.Line, Function: frame.Function})
+		}
+
+		if !more {
+			return stack
+		}
+	}
+}
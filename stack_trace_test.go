@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"runtime"
+	"testing"
+)
+
+type testFrame uintptr
+
+type testStackError struct {
+	pcs []testFrame
+}
+
+func newTestStackError() *testStackError {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := make([]testFrame, n)
+
+	for i := range frames {
+		frames[i] = testFrame(pcs[i])
+	}
+
+	return &testStackError{pcs: frames}
+}
+
+func (e *testStackError) Error() string {
+	return "stack error"
+}
+
+func (e *testStackError) StackTrace() []testFrame {
+	return e.pcs
+}
+
+type testStackTraceError struct {
+	stack StackTrace
+}
+
+func (e *testStackTraceError) Error() string {
+	return "stack trace error"
+}
+
+func (e *testStackTraceError) StackTrace() StackTrace {
+	return e.stack
+}
+
+func TestMainLogger_WithStackTrace(t *testing.T) {
+	t.Parallel()
+
+	handler := NewInMemoryHandler(0)
+	logger := New(handler).WithStackTrace(LevelError)
+
+	logger.Warning("test")
+	assert.Nil(t, handler.Pop().Stack)
+
+	logger.Error("test")
+	stack := handler.Pop().Stack
+
+	if assert.NotEmpty(t, stack) {
+		assert.Equal(t, packagePath+".TestMainLogger_WithStackTrace", stack[0].Function)
+		assert.Equal(t, "testing.tRunner", stack[len(stack)-1].Function)
+	}
+
+	logger.Criticalf("test %s", "formatted")
+	assert.NotEmpty(t, handler.Pop().Stack)
+}
+
+func TestErrorWrappedLogger_OnError_WithStackTrace(t *testing.T) {
+	t.Parallel()
+
+	handler := NewInMemoryHandler(0)
+	logger := New(handler)
+	wrapper := NewErrorWrappedLogger(logger)
+
+	wrapper.OnError(fmt.Errorf("wrapped: %w", newTestStackError()))
+	log := handler.Pop()
+
+	assert.Equal(t, LevelError, log.Level)
+	assert.Equal(t, "wrapped: stack error", log.Message)
+
+	if assert.NotEmpty(t, log.Stack) {
+		assert.Equal(t, packagePath+".TestErrorWrappedLogger_OnError_WithStackTrace", log.Stack[0].Function)
+	}
+
+	wrapper.OnError(errors.New("without stack"))
+	assert.Nil(t, handler.Pop().Stack)
+}
+
+func TestErrorWrappedLogger_OnCritical_WithStackTrace(t *testing.T) {
+	t.Parallel()
+
+	criticalMessage := ""
+	stack := StackTrace{{File: "/app/main.go", Line: 42, Function: "main.main"}}
+
+	handler := NewInMemoryHandler(0)
+	logger := New(handler).WithStackTrace(LevelError)
+	logger.WithCriticalHandler(func(message string, _ Data) {
+		criticalMessage = message
+	})
+
+	NewErrorWrappedLogger(logger).OnCritical(&testStackTraceError{stack: stack}, "key", "val")
+	log := handler.Pop()
+
+	assert.Equal(t, LevelCritical, log.Level)
+	assert.Equal(t, Data{"key": "val"}, log.Data)
+	assert.Equal(t, stack, log.Stack)
+	assert.Equal(t, "stack trace error", criticalMessage)
+}
+
+func TestMainLogger_LogWithStackTrace_AboveCritical(t *testing.T) {
+	t.Parallel()
+
+	var criticalMessages []string
+	stack := StackTrace{{File: "/app/main.go", Line: 42, Function: "main.main"}}
+
+	handler := NewInMemoryHandler(0)
+	logger := New(handler)
+	logger.WithCriticalHandler(func(message string, _ Data) {
+		criticalMessages = append(criticalMessages, message)
+	})
+
+	logger.logWithStackTrace(LevelError, "error", nil, stack)
+	logger.logWithStackTrace(LevelCritical+1, "above critical", nil, stack)
+
+	assert.Equal(t, []string{"above critical"}, criticalMessages)
+	assert.Equal(t, stack, handler.Pop().Stack)
+}
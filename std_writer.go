@@ -0,0 +1,152 @@
+package logger
+
+import (
+	"bytes"
+	"log"
+	"strings"
+)
+
+var stdLevelPrefixes = []struct {
+	prefix string
+	level  Level
+}{
+	{"[DEBUG]", LevelDebug},
+	{"[INFO]", LevelInfo},
+	{"[WARN]", LevelWarning},
+	{"[WARNING]", LevelWarning},
+	{"[ERROR]", LevelError},
+	{"[CRITICAL]", LevelCritical},
+	{"[FATAL]", LevelCritical},
+}
+
+// StdWriter converts each line written to it (e.g. by standard
+// library log.Logger or http.Server.ErrorLog) into Log with
+// configured Level and passes it to MainLogger.
+type StdWriter struct {
+	logger      *MainLogger
+	level       Level
+	prefix      string
+	flags       int
+	detectLevel bool
+}
+
+// NewStdWriter creates StdWriter which logs every line with provided level.
+func NewStdWriter(logger *MainLogger, level Level) *StdWriter {
+	return &StdWriter{logger: logger, level: level}
+}
+
+// NewStdLogger creates standard library *log.Logger which
+// writes all messages to MainLogger with provided level.
+func NewStdLogger(logger *MainLogger, level Level) *log.Logger {
+	return log.New(NewStdWriter(logger, level), "", 0)
+}
+
+// WithPrefixAndFlags allows to strip prefix and header (date, time,
+// file) added by log.Logger created with the same prefix and flags.
+func (w *StdWriter) WithPrefixAndFlags(prefix string, flags int) *StdWriter {
+	w.prefix = prefix
+	w.flags = flags
+
+	return w
+}
+
+// WithLevelDetection enables detecting level from message prefixes
+// like "[WARN]", "[ERROR]" or names of levels registered with RegisterLevel.
+// Detected prefix is removed from message.
+func (w *StdWriter) WithLevelDetection() *StdWriter {
+	w.detectLevel = true
+
+	return w
+}
+
+func (w *StdWriter) Write(p []byte) (int, error) {
+	for _, line := range bytes.Split(p, []byte("\n")) {
+		message := strings.TrimRight(string(line), "\r")
+
+		if message == "" {
+			continue
+		}
+
+		level, message := w.parseLine(message)
+		w.logger.handleWithCritical(w.logger.handleStandardLog(level, message, nil))
+	}
+
+	return len(p), nil
+}
+
+func (w *StdWriter) parseLine(line string) (Level, string) {
+	if w.flags&log.Lmsgprefix == 0 {
+		line = strings.TrimPrefix(line, w.prefix)
+	}
+
+	line = w.stripHeader(line)
+
+	if w.flags&log.Lmsgprefix != 0 {
+		line = strings.TrimPrefix(line, w.prefix)
+	}
+
+	level := w.level
+
+	if w.detectLevel {
+		for _, lp := range stdLevelPrefixes {
+			if len(line) >= len(lp.prefix) && strings.EqualFold(line[:len(lp.prefix)], lp.prefix) {
+				return lp.level, strings.TrimLeft(line[len(lp.prefix):], " ")
+			}
+		}
+
+		if registered, rest, ok := detectRegisteredLevel(line); ok {
+			return registered, rest
+		}
+	}
+
+	return level, line
+}
+
+// detectRegisteredLevel detects prefix with name of level
+// registered with RegisterLevel (e.g. "[NOTICE]").
+func detectRegisteredLevel(line string) (Level, string, bool) {
+	if !strings.HasPrefix(line, "[") {
+		return 0, line, false
+	}
+
+	end := strings.IndexByte(line, ']')
+
+	if end < 0 {
+		return 0, line, false
+	}
+
+	level, err := LevelName(strings.ToLower(line[1:end])).Level()
+
+	if err != nil {
+		return 0, line, false
+	}
+
+	return level, strings.TrimLeft(line[end+1:], " "), true
+}
+
+// stripHeader removes date, time and file parts added by log.Logger.
+func (w *StdWriter) stripHeader(line string) string {
+	if w.flags&log.Ldate != 0 {
+		line = stripField(line)
+	}
+
+	if w.flags&(log.Ltime|log.Lmicroseconds) != 0 {
+		line = stripField(line)
+	}
+
+	if w.flags&(log.Lshortfile|log.Llongfile) != 0 {
+		if idx := strings.Index(line, ": "); idx >= 0 {
+			line = line[idx+2:]
+		}
+	}
+
+	return line
+}
+
+func stripField(line string) string {
+	if idx := strings.IndexByte(line, ' '); idx >= 0 {
+		return line[idx+1:]
+	}
+
+	return line
+}
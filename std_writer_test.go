@@ -0,0 +1,156 @@
+package logger
+
+import (
+	"github.com/stretchr/testify/assert"
+	"log"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStdWriter_Write(t *testing.T) {
+	t.Parallel()
+
+	tm := time.Now()
+
+	mClock := &mockClock{}
+	mClock.On("Now").Return(tm)
+
+	mHandler := &mockHandler{}
+	mHandler.On("Handle", Log{Level: LevelWarning, Message: "first line", Data: Data{}, CreatedAt: tm}).Return(nil)
+	mHandler.On("Handle", Log{Level: LevelWarning, Message: "second line", Data: Data{}, CreatedAt: tm}).Return(nil)
+
+	logger := New(mHandler)
+	logger.WithClock(mClock)
+
+	n, err := NewStdWriter(logger, LevelWarning).Write([]byte("first line\nsecond line\n"))
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, 23, n)
+		mHandler.AssertExpectations(t)
+		mHandler.AssertNumberOfCalls(t, "Handle", 2)
+	}
+}
+
+func TestStdWriter_WithPrefixAndFlags(t *testing.T) {
+	t.Parallel()
+
+	handler := NewInMemoryHandler(0)
+	logger := New(handler)
+
+	flags := []int{
+		0,
+		log.LstdFlags,
+		log.LstdFlags | log.Lmicroseconds | log.Lshortfile,
+		log.Ldate | log.Llongfile | log.Lmsgprefix,
+	}
+
+	for _, fl := range flags {
+		std := log.New(NewStdWriter(logger, LevelError).WithPrefixAndFlags("app: ", fl), "app: ", fl)
+		std.Printf("test %s", "message")
+
+		log := handler.Pop()
+		assert.Equal(t, LevelError, log.Level)
+		assert.Equal(t, "test message", log.Message)
+	}
+}
+
+func TestStdWriter_WithLevelDetection(t *testing.T) {
+	t.Parallel()
+
+	handler := NewInMemoryHandler(0)
+	logger := New(handler)
+	std := log.New(NewStdWriter(logger, LevelInfo).WithLevelDetection(), "", 0)
+
+	expected := map[string]Level{
+		"[DEBUG] test":    LevelDebug,
+		"[warn] test":     LevelWarning,
+		"[WARNING] test":  LevelWarning,
+		"[ERROR]test":     LevelError,
+		"[CRITICAL] test": LevelCritical,
+		"[FATAL] test":    LevelCritical,
+		"test":            LevelInfo,
+	}
+
+	for message, level := range expected {
+		std.Print(message)
+
+		log := handler.Pop()
+		assert.Equal(t, level, log.Level, message)
+		assert.Equal(t, "test", log.Message, message)
+	}
+}
+
+func TestStdWriter_WithLevelDetection_RegisteredLevel(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, RegisterLevel(Level(1700), "verbose"))
+
+	handler := NewInMemoryHandler(0)
+	std := log.New(NewStdWriter(New(handler), LevelInfo).WithLevelDetection(), "", 0)
+
+	std.Print("[VERBOSE] test")
+
+	log := handler.Pop()
+	assert.Equal(t, Level(1700), log.Level)
+	assert.Equal(t, "test", log.Message)
+
+	std.Print("[UNREGISTERED] test")
+
+	log = handler.Pop()
+	assert.Equal(t, LevelInfo, log.Level)
+	assert.Equal(t, "[UNREGISTERED] test", log.Message)
+}
+
+func TestStdWriter_WithCriticalHandler(t *testing.T) {
+	t.Parallel()
+
+	res := ""
+	logger := New(NewInMemoryHandler(0))
+	logger.WithCriticalHandler(func(message string, _ Data) {
+		res = message
+	})
+
+	NewStdLogger(logger, LevelCritical).Print("test message")
+
+	assert.Equal(t, "test message", res)
+}
+
+func TestStdWriter_WithCriticalHandler_LowerLevel(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	handler := NewInMemoryHandler(0)
+	logger := New(handler)
+	logger.WithCriticalHandler(func(_ string, _ Data) {
+		called = true
+	})
+
+	NewStdLogger(logger, LevelInfo).Print("hello")
+
+	assert.False(t, called)
+	assert.Equal(t, "hello", handler.Pop().Message)
+}
+
+func TestNewStdLogger(t *testing.T) {
+	t.Parallel()
+
+	handler := NewInMemoryHandler(0)
+	logger := New(handler).WithCaller(0)
+	logger.SetLevel(LevelInfo)
+
+	server := &http.Server{ErrorLog: NewStdLogger(logger, LevelError)}
+	server.ErrorLog.Printf("http: TLS handshake error from %s", "127.0.0.1")
+	NewStdLogger(logger, LevelDebug).Print("ignored")
+
+	log := handler.Pop()
+	assert.Equal(t, LevelError, log.Level)
+	assert.Equal(t, "http: TLS handshake error from 127.0.0.1", log.Message)
+
+	if assert.NotNil(t, log.Caller) {
+		assert.Equal(t, "std_writer_test.go", filepath.Base(log.Caller.File))
+	}
+
+	assert.True(t, handler.IsEmpty())
+}
@@ -1,12 +1,24 @@
 package logger
 
-import "time"
+import (
+	"context"
+	"io"
+	"time"
+)
 
 type (
 	// CriticalHandleFunc is called when Logger.Critical or MainLogger.Criticalf.
 	CriticalHandleFunc func(message string, data Data)
 	// FailureHandleFunc is called when handler returns an error.
 	FailureHandleFunc func(log Log, err error)
+	// Reopener is implemented by handlers which are able to reopen
+	// their files (e.g. FileHandler) after they were moved.
+	Reopener interface {
+		Reopen() error
+	}
+	// ContextExtractFunc is called for every Log created with
+	// context.Context and returns Data that will be added to Log.
+	ContextExtractFunc func(ctx context.Context) Data
 	// Clock provides time for logs.
 	// Log.CreatedAt value in Log will be created
 	// using Now function.
@@ -34,6 +46,13 @@ type (
 		// field containing specially formatted message for handlers.
 		Format(log Log) FormattedLog
 	}
+	// Compressor compresses rotated log files.
+	Compressor interface {
+		// Compress writes compressed content of src to dst.
+		Compress(dst io.Writer, src io.Reader) error
+		// Extension returns suffix added to compressed file name (e.g. ".gz").
+		Extension() string
+	}
 	// DebugLogger contains only functions for debug log messages.
 	DebugLogger interface {
 		// Debug creates Log with LevelDebug and provided values as
@@ -102,4 +121,42 @@ type (
 		ErrorLogger
 		CriticalLogger
 	}
+	// LevelLogger contains functions that accept Level as parameter
+	// so custom levels (registered with RegisterLevel) can be logged.
+	LevelLogger interface {
+		// Log creates Log with provided level and values as Data in Log.
+		Log(level Level, message string, values ...string)
+		// Logf creates Log with provided level and formats message with
+		// fmt.Sprintf function with all values before passing it to handler.
+		Logf(level Level, message string, values ...interface{})
+	}
+	// FieldLogger contains functions that accept typed fields
+	// instead of string values. Fields are stored in Log.Fields.
+	FieldLogger interface {
+		// Debugw creates Log with LevelDebug and provided fields.
+		Debugw(message string, fields ...Field)
+		// Infow creates Log with LevelInfo and provided fields.
+		Infow(message string, fields ...Field)
+		// Warningw creates Log with LevelWarning and provided fields.
+		Warningw(message string, fields ...Field)
+		// Errorw creates Log with LevelError and provided fields.
+		Errorw(message string, fields ...Field)
+		// Criticalw creates Log with LevelCritical and provided fields.
+		Criticalw(message string, fields ...Field)
+	}
+	// ContextLogger contains functions that accept context.Context
+	// as first parameter. Context is stored in Log.Context and Data
+	// extracted from it is added to Log.Data.
+	ContextLogger interface {
+		// DebugCtx works the same way as Debug but with provided context.
+		DebugCtx(ctx context.Context, message string, values ...string)
+		// InfoCtx works the same way as Info but with provided context.
+		InfoCtx(ctx context.Context, message string, values ...string)
+		// WarningCtx works the same way as Warning but with provided context.
+		WarningCtx(ctx context.Context, message string, values ...string)
+		// ErrorCtx works the same way as Error but with provided context.
+		ErrorCtx(ctx context.Context, message string, values ...string)
+		// CriticalCtx works the same way as Critical but with provided context.
+		CriticalCtx(ctx context.Context, message string, values ...string)
+	}
 )